@@ -0,0 +1,66 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/pkg/config"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+var ConfigCommand = &cobra.Command{
+	Use:     "config",
+	Aliases: []string{"cfg"},
+	Short:   "Config file related commands; e.g. validate, schema, etc.",
+	Long:    "`config` command validates the config file, or prints the JSON Schema of the config file.",
+}
+
+var ConfigValidateCommand = &cobra.Command{
+	Use:     "validate",
+	Aliases: []string{"v"},
+	Long: "validate command validates the config file strictly, including the nested config files and the ones it extends, " +
+		"the unknown fields, the unknown comment styles and the malformed glob patterns are reported.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if _, err := config.NewConfigFromFile(configFile); err != nil {
+			return err
+		}
+		logger.Log.Infoln("The config file is valid:", configFile)
+		return nil
+	},
+}
+
+var ConfigSchemaCommand = &cobra.Command{
+	Use:  "schema",
+	Long: "schema command prints the JSON Schema of the config file, which can be used by editors to validate and complete the config file.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		schema, err := config.Schema()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(schema)
+		return err
+	},
+}
+
+func init() {
+	ConfigCommand.AddCommand(ConfigValidateCommand)
+	ConfigCommand.AddCommand(ConfigSchemaCommand)
+}
@@ -18,7 +18,17 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/pkg/report"
+)
+
+var (
+	reportFormat string
+	reportOutput string
+	since        string
 )
 
 var Header = &cobra.Command{
@@ -34,4 +44,46 @@ func init() {
 	Header.AddCommand(CheckCommand)
 	Header.AddCommand(FixCommand)
 	Header.AddCommand(DiffCommand)
+	Header.AddCommand(MigrateCommand)
+	Header.AddCommand(RemoveCommand)
+	Header.AddCommand(LanguagesCommand)
+
+	for _, cmd := range []*cobra.Command{CheckCommand, FixCommand, DiffCommand, MigrateCommand, RemoveCommand} {
+		cmd.Flags().StringVarP(&reportFormat, "format", "f", "",
+			fmt.Sprintf("also write the results in a machine-readable format, supported formats: %v", report.Formats()))
+		cmd.Flags().StringVarP(&reportOutput, "output", "o", "",
+			"the file to write the machine-readable results to, if not set they are written to the standard output")
+		cmd.PreRunE = validateReportFlags
+		cmd.Flags().StringVar(&since, "since", "",
+			"only process the files changed since the merge base of this git revision (e.g. origin/main, a commit sha) and HEAD, "+
+				"as well as the files changed in the worktree")
+	}
+}
+
+// reportsToStdout tells whether the machine-readable report goes to the standard output,
+// in which case the logs must be written elsewhere not to mess up the report.
+func reportsToStdout() bool {
+	return reportFormat != "" && reportOutput == ""
+}
+
+func validateReportFlags(_ *cobra.Command, _ []string) error {
+	if reportFormat == "" {
+		return nil
+	}
+	_, err := report.ParseFormat(reportFormat)
+	return err
+}
+
+// writeReport writes the machine-readable report of the command if users request one by the --format flag.
+func writeReport(cmd *cobra.Command, r *report.Report) error {
+	if reportFormat == "" {
+		return nil
+	}
+	format, err := report.ParseFormat(reportFormat)
+	if err != nil {
+		return err
+	}
+	r.Command = cmd.Name()
+	r.Version = version
+	return report.Write(r, format, reportOutput)
 }
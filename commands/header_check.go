@@ -23,11 +23,23 @@ import (
 
 	"github.com/apache/skywalking-eyes/pkg/header"
 	"github.com/apache/skywalking-eyes/pkg/logger"
+	"github.com/apache/skywalking-eyes/pkg/report"
 	"github.com/apache/skywalking-eyes/pkg/review"
 
 	"github.com/spf13/cobra"
 )
 
+var cacheFile string
+
+func init() {
+	CheckCommand.Flags().StringVar(&cacheFile, "cache", "",
+		"the file to cache the check results in, so that the unchanged files that passed the check before are skipped, "+
+			"if the flag is given without a value, "+defaultCacheFile+" is used")
+	CheckCommand.Flags().Lookup("cache").NoOptDefVal = defaultCacheFile
+}
+
+const defaultCacheFile = ".license-eye-cache"
+
 var CheckCommand = &cobra.Command{
 	Use:     "check [paths...]",
 	Aliases: []string{"c"},
@@ -36,8 +48,19 @@ var CheckCommand = &cobra.Command{
 		"Accepts files, directories, and glob patterns. " +
 		"If no paths are specified, checks the current directory " +
 		"recursively as defined in the config file.",
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		hasErrors := false
+		var r report.Report
+
+		var cache *header.Cache
+		if cacheFile != "" {
+			c, err := header.LoadCache(cacheFile)
+			if err != nil {
+				return err
+			}
+			cache = c
+		}
+
 		for _, h := range Config.Headers() {
 			var result header.Result
 
@@ -45,6 +68,11 @@ var CheckCommand = &cobra.Command{
 				logger.Log.Debugln("Overriding paths with command line args.")
 				h.Paths = args
 			}
+			h.Since = since
+			if cache != nil {
+				h.Cache = cache
+				h.PathsIgnore = append(h.PathsIgnore, cacheFile)
+			}
 
 			if err := header.Check(h, &result); err != nil {
 				return err
@@ -54,6 +82,10 @@ var CheckCommand = &cobra.Command{
 
 			writeSummaryQuietly(&result)
 
+			if reportFormat != "" {
+				r.Add(&result, explainFailures(&result, h))
+			}
+
 			if result.HasFailure() {
 				if err := review.Header(&result, h); err != nil {
 					logger.Log.Warnln("Failed to create review comments", err)
@@ -62,6 +94,14 @@ var CheckCommand = &cobra.Command{
 				logger.Log.Error(result.Error())
 			}
 		}
+		if cache != nil {
+			if err := cache.Save(); err != nil {
+				logger.Log.Warnln("Failed to save the cache file:", cacheFile, err)
+			}
+		}
+		if err := writeReport(cmd, &r); err != nil {
+			return err
+		}
 		if hasErrors {
 			return fmt.Errorf("one or more files does not have a valid license header")
 		}
@@ -69,6 +109,20 @@ var CheckCommand = &cobra.Command{
 	},
 }
 
+// explainFailures explains why the invalid files fail the check, by diffing their license headers with the configured one.
+func explainFailures(result *header.Result, h *header.ConfigHeader) map[string]string {
+	details := make(map[string]string, len(result.Failure))
+	for _, file := range result.Failure {
+		diff, err := header.DiffFile(file, h)
+		if err != nil {
+			logger.Log.Debugln("Failed to diff file:", file, err)
+			continue
+		}
+		details[file] = diff
+	}
+	return details
+}
+
 func writeSummaryQuietly(result *header.Result) {
 	if summaryFileName := os.Getenv("GITHUB_STEP_SUMMARY"); summaryFileName != "" {
 		summaryFile, err := os.OpenFile(summaryFileName, os.O_WRONLY|os.O_APPEND, 0o644) //nolint:gosec // path from GITHUB_STEP_SUMMARY env var
@@ -26,6 +26,7 @@ import (
 
 	"github.com/apache/skywalking-eyes/pkg/header"
 	"github.com/apache/skywalking-eyes/pkg/logger"
+	"github.com/apache/skywalking-eyes/pkg/report"
 )
 
 var DiffCommand = &cobra.Command{
@@ -42,9 +43,10 @@ var DiffCommand = &cobra.Command{
 		"so every difference shown is a real cause of the check failure: " +
 		"[-text-] is expected by the configured license but missing in the file, " +
 		"{+text+} is in the file but not expected by the configured license.",
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		hasErrors := false
 		var errors []string
+		var r report.Report
 		for _, h := range Config.Headers() {
 			var result header.Result
 
@@ -52,12 +54,14 @@ var DiffCommand = &cobra.Command{
 				logger.Log.Debugln("Overriding paths with command line args.")
 				h.Paths = args
 			}
+			h.Since = since
 
 			if err := header.Check(h, &result); err != nil {
 				return err
 			}
 
 			sort.Strings(result.Failure)
+			details := make(map[string]string, len(result.Failure))
 			for _, file := range result.Failure {
 				diff, err := header.DiffFile(file, h)
 				if err != nil {
@@ -67,8 +71,12 @@ var DiffCommand = &cobra.Command{
 				if diff == "" {
 					continue
 				}
-				fmt.Printf("%v:\n\t%v\n", file, diff)
+				details[file] = diff
+				if !reportsToStdout() {
+					fmt.Printf("%v:\n\t%v\n", file, diff)
+				}
 			}
+			r.Add(&result, details)
 
 			logger.Log.Infoln(result.String())
 
@@ -76,6 +84,9 @@ var DiffCommand = &cobra.Command{
 				hasErrors = true
 			}
 		}
+		if err := writeReport(cmd, &r); err != nil {
+			return err
+		}
 		if len(errors) > 0 {
 			return fmt.Errorf("%s", strings.Join(errors, "\n"))
 		}
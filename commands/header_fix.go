@@ -19,12 +19,19 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/apache/skywalking-eyes/pkg/header"
 	"github.com/apache/skywalking-eyes/pkg/logger"
+	"github.com/apache/skywalking-eyes/pkg/report"
+)
+
+var (
+	dryRun    bool
+	patchFile string
 )
 
 var FixCommand = &cobra.Command{
@@ -35,8 +42,17 @@ var FixCommand = &cobra.Command{
 		"Accepts files, directories, and glob patterns. " +
 		"If no paths are specified, fixes the current directory " +
 		"recursively as defined in the config file.",
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if patchToStdout() && reportsToStdout() {
+			return fmt.Errorf("both the patch and the report go to the standard output, use --patch or --output to write one of them to a file")
+		}
+		var patch *header.Patch
+		if dryRun || patchFile != "" {
+			patch = new(header.Patch)
+		}
+
 		var errors []string
+		var r report.Report
 		for _, h := range Config.Headers() {
 			var result header.Result
 
@@ -44,22 +60,72 @@ var FixCommand = &cobra.Command{
 				logger.Log.Debugln("Overriding paths with command line args.")
 				h.Paths = args
 			}
+			h.Since = since
+			h.DryRun = patch
 
 			if err := header.Check(h, &result); err != nil {
 				return err
 			}
 
+			details := make(map[string]string)
 			for _, file := range result.Failure {
 				if err := header.Fix(file, h, &result); err != nil {
 					errors = append(errors, err.Error())
+					details[file] = err.Error()
 				}
 			}
+			r.Add(&result, details)
 
 			logger.Log.Infoln(result.String())
 		}
+		if patch != nil {
+			if err := writePatch(patch); err != nil {
+				return err
+			}
+		}
+		if err := writeReport(cmd, &r); err != nil {
+			return err
+		}
 		if len(errors) > 0 {
 			return fmt.Errorf("%s", strings.Join(errors, "\n"))
 		}
 		return nil
 	},
 }
+
+func init() {
+	for _, cmd := range []*cobra.Command{FixCommand, MigrateCommand, RemoveCommand} {
+		cmd.Flags().BoolVar(&dryRun, "dry-run", false,
+			"print the changes as a unified diff to the standard output instead of writing them to the files")
+		cmd.Flags().StringVar(&patchFile, "patch", "",
+			"write the changes as a unified diff to this patch file instead of writing them to the files, implies --dry-run")
+	}
+}
+
+// patchToStdout tells whether the dry-run patch goes to the standard output,
+// in which case the logs must be written elsewhere not to mess up the patch.
+func patchToStdout() bool {
+	return dryRun && patchFile == ""
+}
+
+// writePatch writes the dry-run patch to the patch file, or to the standard output if it's not set,
+// the patch file is not written if there is no change.
+func writePatch(patch *header.Patch) error {
+	if patch.Empty() && patchFile != "" {
+		logger.Log.Infoln("Nothing to change, the patch file is not written:", patchFile)
+		return nil
+	}
+	if patchFile == "" {
+		return patch.Encode(os.Stdout)
+	}
+	file, err := os.Create(patchFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := patch.Encode(file); err != nil {
+		return err
+	}
+	logger.Log.Infoln("The changes are written to the patch file:", patchFile)
+	return nil
+}
@@ -0,0 +1,54 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+)
+
+var LanguagesCommand = &cobra.Command{
+	Use:     "languages <files...>",
+	Aliases: []string{"l"},
+	Long: "languages command prints the language and the comment style resolved for each of the specified files, " +
+		"and the rules by which they are chosen, i.e. the file name, the extension, the shebang or the modeline, " +
+		"and the comment-styles in the config file.",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, file := range args {
+			resolution := comments.Resolve(file)
+			if resolution.Reason == "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: unknown language and comment style\n", file)
+				continue
+			}
+			language, style := "unknown", "none"
+			if resolution.Language != "" {
+				language = resolution.Language
+			}
+			if resolution.Style != nil {
+				style = resolution.Style.ID
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: language %s, comment style %s, resolved by %s\n",
+				file, language, style, resolution.Reason)
+		}
+		return nil
+	},
+}
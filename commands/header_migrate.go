@@ -0,0 +1,95 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/pkg/header"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+	"github.com/apache/skywalking-eyes/pkg/report"
+)
+
+var MigrateCommand = &cobra.Command{
+	Use:     "migrate [paths...]",
+	Aliases: []string{"m"},
+	Long: "migrate command walks the specified paths recursively and replaces the existing license " +
+		"header of any known license (e.g. GPL, MIT) with the configured one, the files that " +
+		"don't have a license header get the configured one inserted, just like the fix command. " +
+		"Accepts files, directories, and glob patterns. " +
+		"If no paths are specified, migrates the current directory " +
+		"recursively as defined in the config file.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if patchToStdout() && reportsToStdout() {
+			return fmt.Errorf("both the patch and the report go to the standard output, use --patch or --output to write one of them to a file")
+		}
+		var patch *header.Patch
+		if dryRun || patchFile != "" {
+			patch = new(header.Patch)
+		}
+
+		var errors []string
+		var r report.Report
+		for _, h := range Config.Headers() {
+			var result header.Result
+
+			if len(args) > 0 {
+				logger.Log.Debugln("Overriding paths with command line args.")
+				h.Paths = args
+			}
+			h.Since = since
+			h.DryRun = patch
+
+			if err := header.Check(h, &result); err != nil {
+				return err
+			}
+
+			details := make(map[string]string)
+			for _, file := range result.Failure {
+				replaced, err := header.Migrate(file, h, &result)
+				if err != nil {
+					errors = append(errors, err.Error())
+					details[file] = err.Error()
+					continue
+				}
+				if replaced != "" {
+					logger.Log.Infof("Replaced the %v license header with the configured one: %v", replaced, file)
+					details[file] = fmt.Sprintf("replaced the %v license header", replaced)
+				}
+			}
+			r.Add(&result, details)
+
+			logger.Log.Infoln(result.String())
+		}
+		if patch != nil {
+			if err := writePatch(patch); err != nil {
+				return err
+			}
+		}
+		if err := writeReport(cmd, &r); err != nil {
+			return err
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("%s", strings.Join(errors, "\n"))
+		}
+		return nil
+	},
+}
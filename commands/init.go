@@ -0,0 +1,63 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/pkg/config"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+var force bool
+
+var InitCommand = &cobra.Command{
+	Use: "init",
+	Long: "init command generates a starter config file from the files in the current directory, the license, the copyright owner, " +
+		"the files to ignore and the dependency files are detected, and written to the file given by --config.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if _, err := os.Stat(configFile); err == nil && !force {
+			return fmt.Errorf("the config file %v already exists, use --force to overwrite it", configFile)
+		}
+
+		starter, err := config.Detect()
+		if err != nil {
+			return err
+		}
+		content := starter.YAML()
+		if _, err := config.Parse(configFile, []byte(content)); err != nil {
+			return fmt.Errorf("the generated config file is invalid: %w", err)
+		}
+		if err := os.WriteFile(configFile, []byte(content), 0o644); err != nil { //nolint:gosec // the config file given by users
+			return err
+		}
+
+		logger.Log.Infoln("The config file is generated:", configFile)
+		if starter.SpdxID == "" {
+			logger.Log.Warnln("The license of the project is not identified, please set it in the config file")
+		}
+		return nil
+	},
+}
+
+func init() {
+	InitCommand.Flags().BoolVar(&force, "force", false, "overwrite the config file if it exists")
+}
@@ -18,6 +18,8 @@
 package commands
 
 import (
+	"os"
+
 	"github.com/apache/skywalking-eyes/pkg/config"
 	"github.com/apache/skywalking-eyes/pkg/logger"
 
@@ -46,6 +48,14 @@ var root = &cobra.Command{
 			return err
 		}
 		logger.Log.SetLevel(level)
+		if reportsToStdout() || patchToStdout() {
+			logger.Log.SetOutput(os.Stderr)
+		}
+
+		if cmd == InitCommand || cmd.Parent() == ConfigCommand {
+			// these commands load the config file by themselves, if they need it
+			return nil
+		}
 
 		Config, err = config.NewConfigFromFile(configFile)
 		return err
@@ -61,6 +71,8 @@ func Execute() error {
 
 	root.AddCommand(Header)
 	root.AddCommand(Deps)
+	root.AddCommand(ConfigCommand)
+	root.AddCommand(InitCommand)
 
 	return root.Execute()
 }
@@ -3,6 +3,7 @@ module github.com/apache/skywalking-eyes
 go 1.25.3
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/Masterminds/sprig/v3 v3.2.3
 	github.com/bmatcuk/doublestar/v2 v2.0.4
 	github.com/go-git/go-billy/v5 v5.9.0
@@ -19,6 +19,7 @@ package comments
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/apache/skywalking-eyes/assets"
@@ -40,6 +41,9 @@ func (style *CommentStyle) Validate() error {
 	if style.Start == "" || strings.TrimSpace(style.Start) == "" {
 		return fmt.Errorf("comment style 'start' cannot be empty")
 	}
+	if _, err := regexp.Compile(style.After); err != nil {
+		return fmt.Errorf("comment style 'after' is not a valid regular expression: %w", err)
+	}
 	return nil
 }
 
@@ -48,18 +52,24 @@ type Language struct {
 	Extensions     []string `yaml:"extensions"`
 	Filenames      []string `yaml:"filenames"`
 	CommentStyleID string   `yaml:"comment_style_id"`
+	// Interpreters are the interpreters in the shebangs of the files in the language, e.g. "bash" of Shell.
+	Interpreters []string `yaml:"interpreters"`
+	// Aliases are the other names of the language, which can be used in the Vim/Emacs modelines.
+	Aliases []string `yaml:"aliases"`
 }
 
 var languages map[string]Language
 var comments = make(map[string]CommentStyle)
-var commentStyles = make(map[string]CommentStyle)
+
+// customStyles are the comment styles declared in the config files, keyed by the IDs.
+var customStyles = make(map[string]CommentStyle)
 
 func init() {
 	initLanguages()
 
 	initCommentStyles()
 
-	initLanguageCommentStyles(languages)
+	initCandidates()
 }
 
 func initLanguages() {
@@ -93,35 +103,62 @@ func initCommentStyles() {
 	}
 }
 
-func initLanguageCommentStyles(languages map[string]Language) {
-	if len(languages) == 0 {
-		return
-	}
-	for _, lang := range languages {
-		for _, extension := range lang.Extensions {
-			if lang.CommentStyleID == "" {
-				continue
-			}
-			commentStyles[extension] = comments[lang.CommentStyleID]
+// FileLanguage returns the name of the language of the file, it returns false if there isn't one.
+// See Resolve for the precedence of the languages.
+func FileLanguage(filename string) (string, bool) {
+	resolution := Resolve(filename)
+	return resolution.Language, resolution.Language != ""
+}
+
+// StyleExists tells whether the comment style of the id is declared.
+func StyleExists(id string) bool {
+	_, ok := comments[id]
+	return ok
+}
+
+// DeclareCommentStyles declares the comment styles in the config file, so that they can be used by the languages
+// and the comment-styles in the config file, like the built-in ones. The IDs of the comment styles must not be
+// the built-in ones, or the ones declared differently before.
+func DeclareCommentStyles(styles []CommentStyle) error {
+	for _, style := range styles {
+		if style.ID == "" {
+			return fmt.Errorf("the id of the comment style is required")
+		}
+		if err := style.Validate(); err != nil {
+			return fmt.Errorf("invalid comment style %q: %w", style.ID, err)
 		}
-		for _, filename := range lang.Filenames {
-			if lang.CommentStyleID == "" {
-				continue
+		if declared, ok := comments[style.ID]; ok {
+			if _, custom := customStyles[style.ID]; !custom {
+				return fmt.Errorf("the comment style %q is built-in, use another id", style.ID)
+			}
+			if declared != style {
+				return fmt.Errorf("the comment style %q is declared differently in another header section or config file", style.ID)
 			}
-			commentStyles[filename] = comments[lang.CommentStyleID]
 		}
+		customStyles[style.ID] = style
+		comments[style.ID] = style
 	}
+	return nil
+}
+
+// LanguageExists tells whether the language of the name is built-in or declared in the config file.
+func LanguageExists(name string) bool {
+	_, builtin := languages[name]
+	_, custom := customLanguages[name]
+	return builtin || custom
 }
 
+// FileCommentStyle returns the comment style of the language of the file, or nil if the comment style is unknown.
+// See Resolve for the precedence of the languages.
 func FileCommentStyle(filename string) *CommentStyle {
-	for extension, style := range commentStyles {
-		if strings.HasSuffix(filename, extension) {
-			return &style
-		}
-	}
-	return nil
+	return Resolve(filename).Style
 }
 
+// OverrideLanguageCommentStyle declares the languages in the config file, which take precedence over the built-in
+// languages matching the files equally well.
 func OverrideLanguageCommentStyle(languages map[string]Language) {
-	initLanguageCommentStyles(languages)
+	for name, language := range languages {
+		customLanguages[name] = language
+	}
+	initCandidates()
 }
@@ -17,7 +17,12 @@
 
 package comments
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
 
 func TestConfig(t *testing.T) {
 	if len(languages) == 0 {
@@ -63,3 +68,101 @@ func TestCommentStyle(t *testing.T) {
 		})
 	}
 }
+
+func TestFileLanguage(t *testing.T) {
+	tests := []struct {
+		file string
+		lang string
+	}{
+		{file: "main.go", lang: "Go"},
+		{file: "src/app.py", lang: "Python"},
+		{file: "Dockerfile", lang: "Dockerfile"},
+		{file: "unknown.nosuchextension", lang: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			if lang, _ := FileLanguage(test.file); lang != test.lang {
+				t.Errorf("FileLanguage(%q) = %q, want %q", test.file, lang, test.lang)
+			}
+		})
+	}
+}
+
+func TestFileCommentStyleFromContent(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		content string
+		styleID string
+	}{
+		{content: "#!/usr/bin/env bash\necho hello\n", styleID: "Hashtag"},
+		{content: "#!/bin/sh\n", styleID: "Hashtag"},
+		{content: "#!/usr/bin/python3.11\nprint('hello')\n", styleID: "PythonStyle"},
+		{content: "#!/usr/bin/env -S NODE_ENV=production node --harmony\n", styleID: "SlashAsterisk"},
+		{content: "puts 'hello'\n# vim: set ft=ruby:\n", styleID: "Hashtag"},
+		{content: "# -*- mode: python; coding: utf-8 -*-\nprint('hello')\n", styleID: "PythonStyle"},
+		{content: "// -*- C++ -*-\nint main() {}\n", styleID: "SlashAsterisk"},
+		{content: "x = 1\n" + strings.Repeat("filler line\n", 1000) + "# vim: ft=sh\n", styleID: "Hashtag"},
+		{content: "#!/usr/bin/env no-such-interpreter\n", styleID: ""},
+		{content: "hello\n", styleID: ""},
+	}
+	for i, test := range tests {
+		file := filepath.Join(dir, "script"+strings.Repeat("x", i))
+		if err := os.WriteFile(file, []byte(test.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		styleID := ""
+		if style := FileCommentStyle(file); style != nil {
+			styleID = style.ID
+		}
+		if styleID != test.styleID {
+			t.Errorf("FileCommentStyle(%q) = %q, want %q", test.content[:min(len(test.content), 40)], styleID, test.styleID)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Cleanup(Snapshot())
+	OverrideLanguageCommentStyle(map[string]Language{
+		"Proto": {Extensions: []string{".proto"}, CommentStyleID: "Hashtag"},
+		"MyDSL": {Extensions: []string{".dsl", ".py"}, CommentStyleID: "DoubleDash"},
+	})
+	OverrideCommentStyles(map[string]string{"sql/**/*.txt": "DoubleDash", "sql/**": "Hashtag", "nginx/*.conf": "Hashtag"})
+
+	tests := []struct {
+		file    string
+		lang    string
+		styleID string
+		reason  string
+	}{
+		{file: "CMakeLists.txt", lang: "CMake", styleID: "Hashtag", reason: `the file name "CMakeLists.txt"`},
+		{file: "cmake/modules.cmake", lang: "CMake", styleID: "Hashtag", reason: `the extension ".cmake"`},
+		{file: "types.d.ts", lang: "TypeScript", styleID: "SlashAsterisk", reason: `the extension ".ts"`},
+		// the file names of the languages without comment styles don't take precedence over the extensions with
+		{file: "pom.xml", lang: "XML", styleID: "AngleBracket", reason: `the extension ".xml"`},
+		{file: "resources/views/x.blade.php", lang: "PHP", styleID: "PhpTag", reason: `the extension ".php"`},
+		{file: "go.mod", lang: "XML", styleID: "AngleBracket", reason: `the extension ".mod"`},
+		{file: "api.proto", lang: "Proto", styleID: "Hashtag", reason: `the extension ".proto" of the language declared in the config file`},
+		{file: "a.py", lang: "MyDSL", styleID: "DoubleDash", reason: `the extension ".py" of the language declared in the config file`},
+		{file: "unknown.nosuchextension"},
+		{
+			file: "sql/schema/tables.txt", lang: "Text", styleID: "DoubleDash",
+			reason: `the extension ".txt", and the comment style by the pattern "sql/**/*.txt" of the comment-styles in the config file`,
+		},
+		{file: "nginx/site.conf", styleID: "Hashtag", reason: `the pattern "nginx/*.conf" of the comment-styles in the config file`},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				resolution := Resolve(test.file)
+				styleID := ""
+				if resolution.Style != nil {
+					styleID = resolution.Style.ID
+				}
+				if resolution.Language != test.lang || styleID != test.styleID || resolution.Reason != test.reason {
+					t.Fatalf("Resolve(%q) = %q, %q, %q, want %q, %q, %q", test.file,
+						resolution.Language, styleID, resolution.Reason, test.lang, test.styleID, test.reason)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package comments
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// headSize is the size of the head of a file where the shebang and the modelines are looked for.
+	headSize = 4096
+	// tailSize is the size of the tail of a file where the modelines are looked for, as Vim does.
+	tailSize = 1024
+	// modelineLines is the number of the lines at the start and the end of a file where the modelines can be.
+	modelineLines = 5
+)
+
+var (
+	vimModeline   = regexp.MustCompile(`(?:^|\s)(?:vi|vim|ex)(?:[<=>]?\d+)?:.*?[\s:](?:ft|filetype|syntax)=([\w+#.-]+)`)
+	emacsModeline = regexp.MustCompile(`-\*-\s*(?:(?:.*?;)?\s*mode:\s*([\w+#.-]+).*?|([\w+#.-]+)\s*)-\*-`)
+	// versionSuffix is the version of an interpreter, like the "3.11" of "python3.11".
+	versionSuffix = regexp.MustCompile(`[\d.]+$`)
+)
+
+// contentLanguage resolves the language of the file from its content, that is the interpreter of its shebang,
+// or its Vim/Emacs modeline, for the files whose names don't tell their languages, e.g. the scripts without extensions.
+// It returns the reason why the language is resolved as well, or false if there isn't one.
+func contentLanguage(filename string) (name, reason string, ok bool) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", "", false
+	}
+	defer file.Close()
+
+	head := make([]byte, headSize)
+	n, _ := io.ReadFull(file, head)
+	lines := strings.Split(string(head[:n]), "\n")
+
+	if strings.HasPrefix(lines[0], "#!") {
+		interpreter := shebangInterpreter(lines[0])
+		if lang, ok := interpreterLanguage(interpreter); ok {
+			return lang, fmt.Sprintf("the interpreter %q of the shebang", interpreter), true
+		}
+	}
+
+	// the modelines are in the first or the last lines
+	tail := head[:n]
+	if stat, err := file.Stat(); err == nil && stat.Size() > int64(n) {
+		tail = make([]byte, tailSize)
+		m, _ := file.ReadAt(tail, max(stat.Size()-tailSize, int64(n)))
+		tail = tail[:m]
+	}
+	last := strings.Split(strings.TrimRight(string(tail), "\n"), "\n")
+	candidates := append(slices.Clone(lines[:min(len(lines), modelineLines)]), last[max(0, len(last)-modelineLines):]...)
+
+	for _, line := range candidates {
+		if lang, ok := modelineLanguage(line); ok {
+			return lang, fmt.Sprintf("the modeline %q", strings.TrimSpace(line)), true
+		}
+	}
+	return "", "", false
+}
+
+// shebangInterpreter returns the interpreter of the shebang line, e.g. "bash" of "#!/usr/bin/env bash".
+func shebangInterpreter(shebang string) string {
+	fields := strings.Fields(strings.TrimPrefix(shebang, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		// skip the options and the environment variables of env, e.g. "#!/usr/bin/env -S FOO=bar python3 -u"
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+				interpreter = filepath.Base(field)
+				break
+			}
+		}
+	}
+	return interpreter
+}
+
+// interpreterLanguage returns the language of the interpreter, the version of the interpreter is ignored if
+// there is no language of the versioned one, e.g. "python3.11" is "python".
+func interpreterLanguage(interpreter string) (string, bool) {
+	if interpreter == "" {
+		return "", false
+	}
+	for _, candidate := range []string{interpreter, versionSuffix.ReplaceAllString(interpreter, "")} {
+		for _, c := range candidates {
+			if slices.Contains(c.language.Interpreters, candidate) {
+				return c.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// modelineLanguage returns the language of the Vim or Emacs modeline, e.g. "vim: set ft=python:" or "-*- mode: ruby -*-".
+func modelineLanguage(line string) (string, bool) {
+	var mode string
+	if m := vimModeline.FindStringSubmatch(line); m != nil {
+		mode = m[1]
+	} else if m := emacsModeline.FindStringSubmatch(line); m != nil {
+		mode = m[1] + m[2]
+	}
+	if mode == "" {
+		return "", false
+	}
+	mode = strings.ToLower(mode)
+	for _, c := range candidates {
+		if strings.ToLower(c.name) == mode || slices.Contains(c.language.Aliases, mode) {
+			return c.name, true
+		}
+	}
+	return "", false
+}
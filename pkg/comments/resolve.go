@@ -0,0 +1,238 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package comments
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v2"
+)
+
+// Resolution is the language and the comment style resolved for a file, and the reason why they are chosen.
+type Resolution struct {
+	Language string
+	// Style is nil if the language is unknown or has no comment style.
+	Style *CommentStyle
+	// Reason tells which rules the language and the comment style are resolved by, e.g. `the extension ".go"`,
+	// it's empty if neither is resolved.
+	Reason string
+}
+
+// candidate is a language that files can be resolved to.
+type candidate struct {
+	name     string
+	language Language
+	// custom tells whether the language is declared in the config file.
+	custom bool
+}
+
+// customLanguages are the languages declared in the config files, keyed by the language names.
+var customLanguages = make(map[string]Language)
+
+// patternStyles are the comment style IDs of the files matching the doublestar patterns, declared in the
+// comment-styles of the config files, keyed by the patterns.
+var patternStyles = make(map[string]string)
+
+// stylePatterns are the keys of patternStyles in the order of precedence, i.e. the longer (more specific) ones first,
+// then sorted by the patterns.
+var stylePatterns []string
+
+// candidates are all the languages, in the order of precedence when they match a file equally well,
+// i.e. the custom languages, then the built-in ones, sorted by their names respectively.
+var candidates []candidate
+
+func initCandidates() {
+	candidates = candidates[:0]
+	for _, name := range slices.Sorted(maps.Keys(customLanguages)) {
+		candidates = append(candidates, candidate{name: name, language: customLanguages[name], custom: true})
+	}
+	for _, name := range slices.Sorted(maps.Keys(languages)) {
+		candidates = append(candidates, candidate{name: name, language: languages[name]})
+	}
+}
+
+// rank is how well a language matches a file, the ranks are compared field by field, the greater the better.
+type rank struct {
+	// filename tells whether the file name is one of the filenames of the language.
+	filename bool
+	// extension is the length of the longest extension of the language that's a suffix of the file name.
+	extension int
+	// styled tells whether the language has a comment style.
+	styled bool
+	// custom tells whether the language is declared in the config file.
+	custom bool
+	// primary tells whether the extension is the primary (the first) one of the language.
+	primary bool
+}
+
+// match is a language matching a file, with its rank and the reason why it matches.
+type match struct {
+	*candidate
+	rank   rank
+	reason string
+}
+
+func (r rank) greater(o rank) bool {
+	switch {
+	case r.filename != o.filename:
+		return r.filename
+	case r.extension != o.extension:
+		return r.extension > o.extension
+	case r.styled != o.styled:
+		return r.styled
+	case r.custom != o.custom:
+		return r.custom
+	default:
+		return r.primary && !o.primary
+	}
+}
+
+// OverrideCommentStyles declares the comment styles of the files matching the doublestar patterns, which take
+// precedence over the comment styles of the languages of the files.
+func OverrideCommentStyles(styles map[string]string) {
+	for pattern, id := range styles {
+		patternStyles[pattern] = id
+	}
+	stylePatterns = slices.SortedFunc(maps.Keys(patternStyles), func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+}
+
+// Snapshot saves the comment styles, the languages and the comment-styles patterns declared in the config files,
+// and returns the function to restore them, so that the tests declaring them in the same process don't affect each other.
+func Snapshot() (restore func()) {
+	savedComments, savedStyles := maps.Clone(comments), maps.Clone(customStyles)
+	savedLanguages, savedPatterns := maps.Clone(customLanguages), maps.Clone(patternStyles)
+	savedStylePatterns := slices.Clone(stylePatterns)
+	return func() {
+		comments, customStyles = savedComments, savedStyles
+		customLanguages, patternStyles, stylePatterns = savedLanguages, savedPatterns, savedStylePatterns
+		initCandidates()
+	}
+}
+
+// Resolve resolves the language and the comment style of the file deterministically, the precedence is:
+//
+//  1. the language whose filenames have the exact file name, e.g. "CMakeLists.txt";
+//  2. the language whose extension is the longest suffix of the file name, e.g. ".d.ts" over ".ts",
+//     the languages with comment styles take precedence over the others, then the languages declared in the config
+//     file over the built-in ones, then the primary (the first) extensions;
+//  3. the language of the interpreter in the shebang, or the Vim/Emacs modeline of the file.
+//
+// If the best match of 1 and 2 has no comment style, the best one that has is chosen instead, e.g. "pom.xml" is
+// resolved by the extension ".xml" rather than the file name of a language without comment style.
+//
+// The remaining ties are broken by the language names. And the comment styles of the patterns in the comment-styles
+// of the config file override the ones of the languages, the longest pattern wins if several patterns match the file.
+func Resolve(filename string) Resolution {
+	resolution := resolveLanguage(filename)
+	path := filepath.ToSlash(filepath.Clean(filename))
+	for _, pattern := range stylePatterns {
+		if matched, _ := doublestar.Match(pattern, path); !matched {
+			continue
+		}
+		style := comments[patternStyles[pattern]]
+		reason := fmt.Sprintf("the pattern %q of the comment-styles in the config file", pattern)
+		if resolution.Reason != "" {
+			reason = resolution.Reason + ", and the comment style by " + reason
+		}
+		resolution.Style, resolution.Reason = &style, reason
+		break
+	}
+	return resolution
+}
+
+// resolveLanguage resolves the language of the file and its comment style, see Resolve for the precedence.
+func resolveLanguage(filename string) Resolution {
+	base := filepath.Base(filename)
+
+	// best is the best match, and styled is the best match that has a comment style, which is preferred when
+	// the best match has none, e.g. "pom.xml" matches the file name of a language without comment style
+	// and the extension ".xml" of one with.
+	var best, styled match
+	for i := range candidates {
+		c := &candidates[i]
+		r, reason := rank{styled: c.language.CommentStyleID != "", custom: c.custom}, ""
+		if slices.Contains(c.language.Filenames, base) {
+			r.filename, reason = true, fmt.Sprintf("the file name %q", base)
+		} else {
+			for j, extension := range c.language.Extensions {
+				if len(extension) > r.extension && strings.HasSuffix(base, extension) {
+					r.extension, r.primary, reason = len(extension), j == 0, fmt.Sprintf("the extension %q", extension)
+				}
+			}
+		}
+		if reason == "" {
+			continue
+		}
+		if best.candidate == nil || r.greater(best.rank) {
+			best = match{c, r, reason}
+		}
+		if r.styled && (styled.candidate == nil || r.greater(styled.rank)) {
+			styled = match{c, r, reason}
+		}
+	}
+	if !best.rank.styled && styled.candidate != nil {
+		best = styled
+	}
+	if best.candidate != nil {
+		return best.resolution(best.reason)
+	}
+
+	if name, reason, ok := contentLanguage(filename); ok {
+		for i := range candidates {
+			if candidates[i].name == name {
+				return candidates[i].resolution(reason)
+			}
+		}
+	}
+	return Resolution{}
+}
+
+func (c *candidate) resolution(reason string) Resolution {
+	resolution := Resolution{Language: c.name, Reason: reason}
+	if c.custom {
+		resolution.Reason += " of the language declared in the config file"
+	}
+	if style, ok := comments[c.language.CommentStyleID]; ok {
+		resolution.Style = &style
+	}
+	return resolution
+}
+
+// LanguageCommentStyle returns the comment style of the language of the name, which is case-insensitive and can be
+// one of the aliases of the language, e.g. "python" in the metadata of the notebooks, or nil if it's unknown.
+func LanguageCommentStyle(name string) *CommentStyle {
+	name = strings.ToLower(name)
+	for _, c := range candidates {
+		if strings.ToLower(c.name) != name && !slices.Contains(c.language.Aliases, name) {
+			continue
+		}
+		if style, ok := comments[c.language.CommentStyleID]; ok {
+			return &style
+		}
+	}
+	return nil
+}
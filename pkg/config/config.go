@@ -18,7 +18,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/apache/skywalking-eyes/assets"
 	"github.com/apache/skywalking-eyes/pkg/deps"
@@ -34,11 +38,16 @@ type V1 struct {
 }
 
 func ParseV1(filename string, bytes []byte) (*V1, error) {
-	var config V1
-	if err := yaml.Unmarshal(bytes, &config); err != nil {
+	bytes, _, err := extend(filename, bytes)
+	if err != nil {
 		return nil, err
 	}
 
+	var config V1
+	if err := decode(bytes, &config); err != nil {
+		return nil, fmt.Errorf("invalid config file %v: %w", filename, err)
+	}
+
 	if err := config.Header.Finalize(); err != nil {
 		return nil, err
 	}
@@ -58,16 +67,24 @@ func (config *V1) Dependencies() *deps.ConfigDeps {
 }
 
 type V2 struct {
-	Header []*header.ConfigHeader `yaml:"header"`
-	Deps   deps.ConfigDeps        `yaml:"dependency"`
+	// Extends are the base config files that the config file extends, relative to the config file or absolute,
+	// they're merged into the config file when it's parsed, see extend for the merge rules.
+	Extends []string               `yaml:"-"`
+	Header  []*header.ConfigHeader `yaml:"header"`
+	Deps    deps.ConfigDeps        `yaml:"dependency"`
 }
 
 func ParseV2(filename string, bytes []byte) (*V2, error) {
-	var config V2
-	if err := yaml.Unmarshal(bytes, &config); err != nil {
+	bytes, extends, err := extend(filename, bytes)
+	if err != nil {
 		return nil, err
 	}
 
+	config := V2{Extends: extends}
+	if err := decode(bytes, &config); err != nil {
+		return nil, fmt.Errorf("invalid config file %v: %w", filename, err)
+	}
+
 	for _, header := range config.Header {
 		if err := header.Finalize(); err != nil {
 			return nil, err
@@ -113,12 +130,58 @@ func NewConfigFromFile(filename string) (Config, error) {
 		}
 	}
 
-	var config Config
-	if config, err = ParseV2(filename, bytes); err == nil {
-		return config, nil
+	config, err := Parse(filename, bytes)
+	if err != nil {
+		return nil, err
 	}
-	if config, err = ParseV1(filename, bytes); err != nil {
+	return loadNested(config, filename, bytes)
+}
+
+// Parse parses the config file, whose version is decided by the header, which is a mapping in V1, and a list in V2.
+func Parse(filename string, bytes []byte) (Config, error) {
+	merged, _, err := extend(filename, bytes)
+	if err != nil {
 		return nil, err
 	}
-	return config, nil
+	if isV1(merged) {
+		return ParseV1(filename, bytes)
+	}
+	return ParseV2(filename, bytes)
+}
+
+// isV1 tells whether the config file is in V1, where the header is a single section.
+func isV1(content []byte) bool {
+	var doc document
+	return yaml.Unmarshal(content, &doc) == nil && doc.Header.Kind == yaml.MappingNode
+}
+
+// decode decodes the config file strictly, so that the unknown fields, e.g. the typos, are rejected with their line numbers.
+func decode(content []byte, v any) error {
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// checkFields decodes the config file strictly before it's merged with the ones it extends,
+// so that the errors have the line numbers in the config file itself.
+func checkFields(filename string, content []byte) error {
+	var err error
+	if isV1(content) {
+		err = decode(content, &struct {
+			Extends any `yaml:"extends"`
+			V1      `yaml:",inline"`
+		}{})
+	} else {
+		err = decode(content, &struct {
+			Extends any `yaml:"extends"`
+			V2      `yaml:",inline"`
+		}{})
+	}
+	if err != nil {
+		return fmt.Errorf("invalid config file %v: %w", filename, err)
+	}
+	return nil
 }
@@ -0,0 +1,82 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStrictly(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "unknown field in V2",
+			content: "header:\n  - license:\n      spdx-id: Apache-2.0\n    paths-ingore:\n      - '**/*.md'\n",
+			err:     "line 4: field paths-ingore not found",
+		},
+		{
+			name:    "unknown field in V1",
+			content: "header:\n  license:\n    spdx-id: Apache-2.0\n    copyright-ownr: Foo\n",
+			err:     "line 4: field copyright-ownr not found",
+		},
+		{
+			name:    "unknown top-level field",
+			content: "header:\n  license:\n    spdx-id: Apache-2.0\ndependencies:\n  files: []\n",
+			err:     "line 4: field dependencies not found",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(".licenserc.yaml", []byte(test.content))
+			require.ErrorContains(t, err, test.err)
+			require.ErrorContains(t, err, ".licenserc.yaml")
+		})
+	}
+
+	c, err := Parse(".licenserc.yaml", []byte("header:\n  license:\n    spdx-id: Apache-2.0\n"))
+	require.NoError(t, err)
+	require.IsType(t, &V1{}, c)
+	c, err = Parse(".licenserc.yaml", []byte("header:\n  - license:\n      spdx-id: Apache-2.0\n"))
+	require.NoError(t, err)
+	require.IsType(t, &V2{}, c)
+}
+
+func TestParseStrictlyExtended(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("header:\n  license:\n    spdx-id: Apache-2.0\n  paths-ingore: []\n"), 0o600))
+
+	_, err := Parse(filepath.Join(dir, ".licenserc.yaml"), []byte("extends: base.yaml\n"))
+	require.ErrorContains(t, err, "line 4: field paths-ingore not found")
+	require.ErrorContains(t, err, base, "the error should be reported in the base config file")
+}
+
+func TestSchemaUpToDate(t *testing.T) {
+	schema, err := Schema()
+	require.NoError(t, err)
+	published, err := os.ReadFile("../../assets/licenserc.schema.json")
+	require.NoError(t, err)
+	require.Equal(t, string(published), string(schema),
+		"the schema is outdated, run `license-eye config schema > assets/licenserc.schema.json` to update it")
+}
@@ -0,0 +1,226 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// extend merges the base config files that the config file extends into the config file, the base config files
+// can extend other ones too, it returns the merged config file, and the base config files it extends directly.
+//
+// The merge rules are:
+//   - the mappings are merged key by key, the values of the config file override the ones of the base;
+//   - the lists are concatenated, the items of the config file come first so that they take precedence,
+//     except that the paths are replaced;
+//   - every header section of the config file is merged over the header section of the base if the base has only one,
+//     otherwise over the header section of the base at the same index, and the license content and pattern of the
+//     base are dropped if the config file sets another spdx-id without content;
+//   - the relative dependency files are resolved against the directory of the file that declares them.
+func extend(filename string, bs []byte) ([]byte, []string, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	root, extends, err := loadExtended(abs, bs, map[string]bool{abs: true})
+	if err != nil || len(extends) == 0 {
+		return bs, extends, err
+	}
+	merged, err := yaml.Marshal(root)
+	return merged, extends, err
+}
+
+// loadExtended loads the config file and the base config files it extends recursively, merged in a YAML node,
+// visiting are the config files being loaded, to detect the circular extends.
+func loadExtended(filename string, bs []byte, visiting map[string]bool) (node *yaml.Node, extends []string, err error) {
+	if err := checkFields(filename, bs); err != nil {
+		return nil, nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return &doc, nil, nil
+	}
+	root := doc.Content[0]
+
+	if value := child(root, "extends"); value != nil {
+		if err := value.Decode(&extends); err != nil {
+			var single string
+			if value.Decode(&single) != nil {
+				return nil, nil, fmt.Errorf("invalid extends in %v, expect a list of config files: %w", filename, err)
+			}
+			extends = []string{single}
+		}
+		removeKey(root, "extends")
+	}
+
+	var merged *yaml.Node
+	for _, base := range extends {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(filename), base)
+		}
+		if visiting[base] {
+			return nil, nil, fmt.Errorf("circular extends of the config file %v", base)
+		}
+		content, err := os.ReadFile(base)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the config file %v extended by %v: %w", base, filename, err)
+		}
+		visiting[base] = true
+		node, _, err := loadExtended(base, content, visiting)
+		delete(visiting, base)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(node.Content) > 0 {
+			resolveDepFiles(node.Content[0], filepath.Dir(base))
+			merged = mergeNode(merged, node.Content[0], "")
+		}
+	}
+
+	doc.Content[0] = mergeNode(merged, root, "")
+	return &doc, extends, nil
+}
+
+// resolveDepFiles resolves the relative dependency files of the config against the dir.
+func resolveDepFiles(config *yaml.Node, dir string) {
+	dependency := child(config, "dependency")
+	if dependency == nil {
+		return
+	}
+	if files := child(dependency, "files"); files != nil && files.Kind == yaml.SequenceNode {
+		for _, file := range files.Content {
+			if file.Kind == yaml.ScalarNode && !filepath.IsAbs(file.Value) {
+				if abs, err := filepath.Abs(filepath.Join(dir, file.Value)); err == nil {
+					file.Value = abs
+				}
+			}
+		}
+	}
+}
+
+// mergeNode merges the node over the base, key is the mapping key of the node, see extend for the merge rules.
+func mergeNode(base, node *yaml.Node, key string) *yaml.Node {
+	if base == nil {
+		return node
+	}
+
+	if key == "header" {
+		return mergeSections(base, node)
+	}
+
+	switch {
+	case base.Kind == yaml.MappingNode && node.Kind == yaml.MappingNode:
+		if key == "license" && child(node, "spdx-id") != nil && child(node, "content") == nil {
+			base = copyNode(base)
+			removeKey(base, "content")
+			removeKey(base, "pattern")
+		}
+		merged := copyNode(base)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if j := keyIndex(merged, k.Value); j >= 0 {
+				merged.Content[j+1] = mergeNode(merged.Content[j+1], v, k.Value)
+			} else {
+				merged.Content = append(merged.Content, k, v)
+			}
+		}
+		return merged
+	case base.Kind == yaml.SequenceNode && node.Kind == yaml.SequenceNode && key != "paths":
+		merged := copyNode(node)
+		merged.Content = append(merged.Content, base.Content...)
+		return merged
+	}
+	return node
+}
+
+// mergeSections merges the header sections over the ones of the base, the header is a single section in V1, and a
+// list in V2. Every section is merged over the base section if the base has only one, otherwise the sections are
+// merged over the base sections by index, the remaining sections of the base and of the node are kept as they are.
+func mergeSections(base, node *yaml.Node) *yaml.Node {
+	bases := []*yaml.Node{base}
+	if base.Kind == yaml.SequenceNode {
+		bases = base.Content
+	}
+	sections := []*yaml.Node{node}
+	switch {
+	case len(bases) == 0:
+		return node
+	case node.Kind == yaml.SequenceNode:
+		sections = node.Content
+	case node.Kind != yaml.MappingNode:
+		return node
+	}
+
+	n := len(sections)
+	if len(bases) > 1 {
+		n = max(n, len(bases))
+	}
+	merged := make([]*yaml.Node, n)
+	for i := range merged {
+		switch {
+		case len(bases) == 1:
+			merged[i] = mergeNode(bases[0], sections[i], "")
+		case i >= len(sections):
+			merged[i] = bases[i]
+		case i >= len(bases):
+			merged[i] = sections[i]
+		default:
+			merged[i] = mergeNode(bases[i], sections[i], "")
+		}
+	}
+
+	if node.Kind == yaml.MappingNode && len(merged) == 1 {
+		return merged[0]
+	}
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: merged}
+}
+
+// copyNode returns a shallow copy of the node, whose content can be changed without affecting the node.
+func copyNode(node *yaml.Node) *yaml.Node {
+	c := *node
+	c.Content = append([]*yaml.Node(nil), node.Content...)
+	return &c
+}
+
+// keyIndex returns the index of the key in the mapping node, or -1 if there isn't such a key.
+func keyIndex(node *yaml.Node, key string) int {
+	if node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeKey removes the key and its value from the mapping node.
+func removeKey(node *yaml.Node, key string) {
+	if i := keyIndex(node, key); i >= 0 {
+		node.Content = append(node.Content[:i], node.Content[i+2:]...)
+	}
+}
@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+)
+
+func TestExtends(t *testing.T) {
+	t.Cleanup(comments.Snapshot())
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"org/base.yaml": `
+header:
+  license:
+    spdx-id: Apache-2.0
+    copyright-owner: Org
+    content: |
+      Copyright [owner]
+  paths-ignore:
+    - 'dist/**'
+  language:
+    Go:
+      extensions: [".go"]
+      comment_style_id: DoubleSlash
+dependency:
+  files:
+    - go.mod
+  licenses:
+    - name: example.com/a
+      license: MIT
+`,
+		"repo/.licenserc.yaml": `
+extends:
+  - ../org/base.yaml
+header:
+  - license:
+      spdx-id: MIT
+    paths:
+      - 'src/**'
+    paths-ignore:
+      - '**/*.md'
+dependency:
+  licenses:
+    - name: example.com/a
+      license: BSD-3-Clause
+`,
+		"org/sections.yaml": `
+header:
+  - license:
+      spdx-id: Apache-2.0
+      copyright-owner: Org
+    paths: ['src/**']
+  - license:
+      spdx-id: MIT
+      copyright-owner: Org
+    paths: ['web/**']
+`,
+		"sections/.licenserc.yaml": `
+extends: ../org/sections.yaml
+header:
+  license:
+    copyright-owner: Foo
+`,
+		"cycle/a.yaml": "extends: b.yaml\n",
+		"cycle/b.yaml": "extends: [a.yaml]\n",
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	}
+
+	file := filepath.Join(dir, "repo/.licenserc.yaml")
+	bs, err := os.ReadFile(file)
+	require.NoError(t, err)
+	c, err := ParseV2(file, bs)
+	require.NoError(t, err)
+	require.Equal(t, []string{"../org/base.yaml"}, c.Extends)
+
+	require.Len(t, c.Header, 1)
+	h := c.Header[0]
+	require.Equal(t, "MIT", h.License.SpdxID)
+	require.Equal(t, "Org", h.License.CopyrightOwner)
+	require.Empty(t, h.License.Content, "the content of the base license should be dropped for another spdx-id")
+	require.Equal(t, []string{"src/**"}, h.Paths)
+	require.Equal(t, []string{"**/*.md", "dist/**"}, h.PathsIgnore)
+	require.Contains(t, h.Languages, "Go")
+
+	require.Equal(t, []string{filepath.Join(dir, "org/go.mod")}, c.Deps.Files)
+	license, ok := c.Deps.GetUserConfiguredLicense("example.com/a", "v1.0.0")
+	require.True(t, ok)
+	require.Equal(t, "BSD-3-Clause", license, "the licenses of the config file should take precedence over the base")
+
+	file = filepath.Join(dir, "sections/.licenserc.yaml")
+	bs, err = os.ReadFile(file)
+	require.NoError(t, err)
+	c, err = ParseV2(file, bs)
+	require.NoError(t, err)
+	require.Len(t, c.Header, 2, "the header sections of the base should be merged by index")
+	require.Equal(t, "Apache-2.0", c.Header[0].License.SpdxID)
+	require.Equal(t, "Foo", c.Header[0].License.CopyrightOwner)
+	require.Equal(t, []string{"src/**"}, c.Header[0].Paths)
+	require.Equal(t, "MIT", c.Header[1].License.SpdxID)
+	require.Equal(t, "Org", c.Header[1].License.CopyrightOwner)
+	require.Equal(t, []string{"web/**"}, c.Header[1].Paths)
+
+	file = filepath.Join(dir, "cycle/a.yaml")
+	bs, err = os.ReadFile(file)
+	require.NoError(t, err)
+	_, err = ParseV2(file, bs)
+	require.ErrorContains(t, err, "circular extends")
+}
@@ -0,0 +1,243 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	"github.com/apache/skywalking-eyes/pkg/deps"
+	"github.com/apache/skywalking-eyes/pkg/license"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+)
+
+var (
+	// licenseFiles are the names of the license file at the root of a project.
+	licenseFiles = []string{"LICENSE", "LICENSE.txt", "LICENSE.md", "LICENCE", "COPYING"}
+	// generatedDirs are the names of the directories that usually contain the generated or vendored files.
+	generatedDirs = []string{"vendor", "node_modules", "third_party", "third-party", "dist", "build", "target", "out", "generated"}
+	// generatedFiles are the patterns of the files that are usually generated.
+	generatedFiles = []string{"*.pb.go", "*_generated.go", "*.gen.go", "*.min.js", "*.min.css"}
+
+	copyrightLine = regexp.MustCompile(`(?im)copyright\s+(?:\(c\)\s*|©\s*)?(?:\d{4}(?:\s*[-,]\s*\d{4})*\s*,?\s+)?([^\n]+)$`)
+	asfHeader     = regexp.MustCompile(`Licensed to the Apache Software Foundation \(ASF\)`)
+)
+
+// Starter is the starter config file of a project, detected from the files in the project.
+type Starter struct {
+	// SpdxID is the license of the project identified from its license file, or empty if it's not identified.
+	SpdxID string
+	// Owner is the copyright owner found in the existing license headers, or the git user name.
+	Owner string
+	// Languages are the languages of the files in the project.
+	Languages []string
+	// PathsIgnore are the generated and vendored files, and the files without comment styles to have license headers.
+	PathsIgnore []string
+	// Dependencies are the dependency files that can be resolved.
+	Dependencies []string
+}
+
+// Detect detects the starter config file of the project in the current directory.
+func Detect() (*Starter, error) {
+	starter := &Starter{SpdxID: detectLicense()}
+
+	languages := make(map[string]bool)
+	ignores := make(map[string]bool)
+	owners := make(map[string]int)
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			switch {
+			case path == ".":
+				return nil
+			case strings.HasPrefix(name, "."):
+				return filepath.SkipDir
+			case slices.Contains(generatedDirs, name):
+				ignores[filepath.ToSlash(path)+"/**"] = true
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		path = filepath.ToSlash(path)
+		if !strings.Contains(path, "testdata/") && slices.ContainsFunc(deps.Resolvers, func(r deps.Resolver) bool { return r.CanResolve(path) }) {
+			starter.Dependencies = append(starter.Dependencies, path)
+		}
+		if slices.Contains(licenseFiles, path) || path == "NOTICE" {
+			ignores[path] = true
+			return nil
+		}
+		for _, pattern := range generatedFiles {
+			if m, _ := filepath.Match(pattern, name); m {
+				ignores["**/"+pattern] = true
+				return nil
+			}
+		}
+		if language, ok := comments.FileLanguage(path); ok {
+			languages[language] = true
+		}
+		if comments.FileCommentStyle(path) == nil {
+			if ext := filepath.Ext(name); ext != "" {
+				ignores["**/*"+ext] = true
+			} else {
+				ignores[path] = true
+			}
+			return nil
+		}
+		if owner := headerOwner(path); owner != "" {
+			owners[owner]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	starter.Languages = slices.Sorted(maps.Keys(languages))
+	starter.PathsIgnore = slices.Sorted(maps.Keys(ignores))
+	starter.Owner = mostCommon(owners)
+	if starter.Owner == "" {
+		starter.Owner = gitUserName()
+	}
+	return starter, nil
+}
+
+// detectLicense identifies the license of the project from its license file.
+func detectLicense() string {
+	for _, file := range licenseFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		id, err := license.Identify(string(content), deps.DefaultCoverageThreshold)
+		if err != nil {
+			logger.Log.Warnln("Failed to identify the license of", file, err)
+			return ""
+		}
+		// only the first license is used if it's a dual-license
+		return strings.Split(id, " and ")[0]
+	}
+	return ""
+}
+
+// headerOwner returns the copyright owner in the license header of the file, if there is one.
+func headerOwner(file string) string {
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	head := make([]byte, 1024)
+	n, _ := f.Read(head)
+	content := string(head[:n])
+
+	if asfHeader.MatchString(content) {
+		return "Apache Software Foundation"
+	}
+	m := copyrightLine.FindStringSubmatch(content)
+	if m == nil {
+		return ""
+	}
+	owner := m[1]
+	if i := strings.Index(strings.ToLower(owner), "all rights reserved"); i >= 0 {
+		owner = owner[:i]
+	}
+	return strings.Trim(owner, " \t\r.,*/#->")
+}
+
+// gitUserName returns the user name in the git config, or an empty string if it's not set.
+func gitUserName() string {
+	if repo, err := git.PlainOpen("."); err == nil {
+		if c, err := repo.ConfigScoped(gitconfig.GlobalScope); err == nil && c.User.Name != "" {
+			return c.User.Name
+		}
+	}
+	if c, err := gitconfig.LoadConfig(gitconfig.GlobalScope); err == nil {
+		return c.User.Name
+	}
+	return ""
+}
+
+func mostCommon(counts map[string]int) string {
+	best := ""
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		if counts[key] > counts[best] {
+			best = key
+		}
+	}
+	return best
+}
+
+// YAML returns the starter config file in YAML, with the comments to explain the detected settings.
+func (starter *Starter) YAML() string {
+	var sb strings.Builder
+	sb.WriteString("# The config file of license-eye generated by `license-eye init`, see\n")
+	sb.WriteString("# https://github.com/apache/skywalking-eyes#configurations for all the options.\n")
+	sb.WriteString("header:\n")
+	sb.WriteString("  - license:\n")
+	if starter.SpdxID != "" {
+		fmt.Fprintf(&sb, "      spdx-id: %v\n", quote(starter.SpdxID))
+	} else {
+		sb.WriteString("      # The license of the project is not identified, set its spdx-id or the content of the license header.\n")
+		sb.WriteString("      # spdx-id: Apache-2.0\n")
+	}
+	if starter.Owner != "" {
+		fmt.Fprintf(&sb, "      copyright-owner: %v\n", quote(starter.Owner))
+	} else {
+		sb.WriteString("      # copyright-owner: <owner>\n")
+	}
+	if len(starter.Languages) > 0 {
+		fmt.Fprintf(&sb, "    # The languages found in the project: %v.\n", strings.Join(starter.Languages, ", "))
+	}
+	sb.WriteString("    paths:\n")
+	sb.WriteString("      - '**'\n")
+	if len(starter.PathsIgnore) > 0 {
+		sb.WriteString("    # The generated and vendored files, and the files that cannot have license headers.\n")
+		sb.WriteString("    paths-ignore:\n")
+		for _, path := range starter.PathsIgnore {
+			fmt.Fprintf(&sb, "      - %v\n", quote(path))
+		}
+	}
+	sb.WriteString("    comment: on-failure\n")
+	if len(starter.Dependencies) > 0 {
+		sb.WriteString("\ndependency:\n")
+		sb.WriteString("  files:\n")
+		for _, file := range starter.Dependencies {
+			fmt.Fprintf(&sb, "    - %v\n", quote(file))
+		}
+	}
+	return sb.String()
+}
+
+// quote quotes the string in YAML single quotes.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
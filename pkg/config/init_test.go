@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/assets"
+)
+
+func TestDetect(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	mit, err := assets.Asset("lcs-templates/MIT.txt")
+	require.NoError(t, err)
+	files := map[string]string{
+		"LICENSE":               string(mit),
+		"main.go":               "// Copyright 2020-2024 Foo Inc. All rights reserved.\n\npackage main\n",
+		"cmd/tool/main.go":      "// Copyright (c) 2021 Foo Inc.\n\npackage main\n",
+		"util.py":               "# Copyright 2022 Bar\n",
+		"api/api.pb.go":         "package api\n",
+		"vendor/lib/lib.go":     "package lib\n",
+		"web/package.json":      "{}\n",
+		"go.mod":                "module foo\n",
+		"web/node_modules/a.js": "",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	starter, err := Detect()
+	require.NoError(t, err)
+	require.Equal(t, "MIT", starter.SpdxID)
+	require.Equal(t, "Foo Inc", starter.Owner)
+	require.Equal(t, []string{"Go", "JSON", "Python", "XML"}, starter.Languages)
+	require.Equal(t, []string{"**/*.json", "**/*.pb.go", "LICENSE", "vendor/**", "web/node_modules/**"}, starter.PathsIgnore)
+	require.Equal(t, []string{"go.mod", "web/package.json"}, starter.Dependencies)
+
+	c, err := Parse(".licenserc.yaml", []byte(starter.YAML()))
+	require.NoError(t, err)
+	require.Equal(t, "MIT", c.Headers()[0].License.SpdxID)
+	require.Equal(t, "Foo Inc", c.Headers()[0].License.CopyrightOwner)
+	require.Equal(t, starter.PathsIgnore, c.Headers()[0].PathsIgnore)
+}
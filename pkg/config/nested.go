@@ -0,0 +1,315 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+	"github.com/apache/skywalking-eyes/pkg/header"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NestedConfigFile is the name of the config files in the subdirectories, each of them applies to the files
+// under its directory, and inherits the settings of the config file in the nearest parent directory.
+const NestedConfigFile = ".licenserc.yaml"
+
+// document is a config file in YAML nodes, so that a nested config file can be decoded over its parent.
+type document struct {
+	Header yaml.Node `yaml:"header"`
+	Deps   yaml.Node `yaml:"dependency"`
+}
+
+// sections returns the header sections of the document, which is a single one in V1, and a list in V2.
+func (doc *document) sections() []*yaml.Node {
+	switch doc.Header.Kind {
+	case yaml.SequenceNode:
+		return doc.Header.Content
+	case yaml.MappingNode:
+		return []*yaml.Node{&doc.Header}
+	}
+	return nil
+}
+
+// layer is a config file in the hierarchy of the config files.
+type layer struct {
+	// dir is the directory of the config file, which is empty for the root one.
+	dir string
+	// header is the first header section before it's finalized, which is inherited by the nested config files.
+	header header.ConfigHeader
+	// deps is the dependency section before it's finalized, which is inherited by the nested config files.
+	deps deps.ConfigDeps
+	// headers are the finalized header sections.
+	headers []*header.ConfigHeader
+}
+
+// covers tells whether the dir is under the directory of the layer.
+func (l *layer) covers(dir string) bool {
+	return l.dir == "" || strings.HasPrefix(dir+"/", l.dir+"/")
+}
+
+// loadNested loads the nested config files under the current directory, and merges them into the root config.
+func loadNested(root Config, filename string, bs []byte) (Config, error) {
+	files, err := findNested(filename)
+	if err != nil || len(files) == 0 {
+		return root, err
+	}
+
+	if bs, _, err = extend(filename, bs); err != nil {
+		return nil, err
+	}
+	var doc document
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return nil, err
+	}
+	base := &layer{headers: root.Headers()}
+	if sections := doc.sections(); len(sections) > 0 {
+		if err := sections[0].Decode(&base.header); err != nil {
+			return nil, err
+		}
+	}
+	if !doc.Deps.IsZero() {
+		if err := doc.Deps.Decode(&base.deps); err != nil {
+			return nil, err
+		}
+	}
+
+	merged := &V2{Header: root.Headers(), Deps: *root.Dependencies()}
+	layers := []*layer{base}
+	for _, file := range files {
+		dir := filepath.ToSlash(filepath.Dir(file))
+
+		parent := base
+		for _, l := range layers {
+			if l.covers(dir) && len(l.dir) >= len(parent.dir) {
+				parent = l
+			}
+		}
+		if parent.ignores(dir) {
+			logger.Log.Debugln("Ignoring the nested config file in the ignored directory:", file)
+			continue
+		}
+
+		logger.Log.Infoln("Loading nested configuration from file:", file)
+		l, err := parent.nest(file, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		// The files under the directory are checked by the nested config instead of its parents.
+		for _, ancestor := range layers {
+			if len(l.headers) > 0 && ancestor.covers(dir) {
+				for _, h := range ancestor.headers {
+					h.PathsIgnore = append(h.PathsIgnore, dir+"/**")
+				}
+			}
+		}
+
+		merged.Header = append(merged.Header, l.headers...)
+		layers = append(layers, l)
+	}
+
+	for _, l := range layers[1:] {
+		if len(l.deps.Files) > 0 {
+			nested := l.deps
+			merged.Deps.Include(&nested)
+		}
+	}
+	return merged, nil
+}
+
+// findNested finds the nested config files under the current directory, in the lexical order so that the parents
+// come before their subdirectories, the hidden directories and node_modules are skipped.
+func findNested(filename string) ([]string, error) {
+	rootFile, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	err = filepath.WalkDir(".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != "." && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != NestedConfigFile || filepath.Dir(p) == "." {
+			return nil
+		}
+		if abs, err := filepath.Abs(p); err == nil && abs == rootFile {
+			return nil
+		}
+		files = append(files, p)
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// ignores tells whether the directory is ignored by all the header sections of the layer, in which case the nested
+// config file in it is ignored too. The directory rather than the config file is matched, as the config files
+// themselves are usually ignored, e.g. by ".licenserc.yaml" or "**/*.yaml".
+func (l *layer) ignores(dir string) bool {
+	for _, h := range l.headers {
+		if ignored, err := h.ShouldIgnore(dir + "/"); err == nil && !ignored {
+			return false
+		}
+	}
+	return len(l.headers) > 0
+}
+
+// nest loads the nested config file in the dir, which inherits the settings of the layer.
+func (l *layer) nest(file, dir string) (*layer, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if bs, _, err = extend(file, bs); err != nil {
+		return nil, err
+	}
+	var doc document
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return nil, err
+	}
+
+	nested := &layer{dir: dir}
+	for i, section := range doc.sections() {
+		if child(section, "language") != nil {
+			// the languages are resolved for all the files, which can't be scoped to the directory
+			return nil, fmt.Errorf("invalid header section in %v: language is not supported in nested config files, "+
+				"declare it in the root config file, or use comment-styles instead", file)
+		}
+
+		h := l.header
+		h.Languages = maps.Clone(l.header.Languages)
+		h.Paths, h.PathsIgnore, h.CommentStyles, h.BinaryPaths, h.Preambles = nil, nil, nil, nil, nil
+		if license := child(section, "license"); license != nil && child(license, "spdx-id") != nil && child(license, "content") == nil {
+			// the content and pattern of the parent license don't make sense with another spdx-id
+			h.License.Content, h.License.Pattern = "", ""
+		}
+		if err := section.Decode(&h); err != nil {
+			return nil, err
+		}
+
+		// The paths in the nested config file are relative to its directory.
+		if len(h.Paths) == 0 {
+			h.Paths = []string{"**"}
+		}
+		for j, p := range h.Paths {
+			h.Paths[j] = nestPath(dir, p)
+		}
+		for j, p := range h.PathsIgnore {
+			h.PathsIgnore[j] = nestPath(dir, p)
+		}
+		h.PathsIgnore = append(h.PathsIgnore, l.header.PathsIgnore...)
+		for j, p := range h.BinaryPaths {
+			h.BinaryPaths[j] = nestPath(dir, p)
+		}
+		h.BinaryPaths = append(h.BinaryPaths, l.header.BinaryPaths...)
+		for j := range h.Preambles {
+			for k, p := range h.Preambles[j].Paths {
+				h.Preambles[j].Paths[k] = nestPath(dir, p)
+			}
+		}
+		h.Preambles = append(h.Preambles, l.header.Preambles...)
+		// the comment-styles of the parent are in effect already
+		h.CommentStyles = nestPatterns(dir, h.CommentStyles)
+		h.Dir = dir
+		if i == 0 {
+			nested.header = h
+		}
+
+		finalized := h
+		finalized.Paths = slices.Clone(h.Paths)
+		finalized.PathsIgnore = slices.Clone(h.PathsIgnore)
+		finalized.BinaryPaths = slices.Clone(h.BinaryPaths)
+		finalized.Preambles = slices.Clone(h.Preambles)
+		if err := finalized.Finalize(); err != nil {
+			return nil, fmt.Errorf("invalid header section in %v: %w", file, err)
+		}
+		nested.headers = append(nested.headers, &finalized)
+	}
+	if len(nested.headers) == 0 {
+		nested.header = l.header
+	}
+
+	nested.deps = l.deps
+	nested.deps.Files = nil
+	if !doc.Deps.IsZero() {
+		own := nested.deps
+		own.Licenses, own.Excludes = nil, nil
+		if err := doc.Deps.Decode(&own); err != nil {
+			return nil, err
+		}
+		// The licenses and excludes of the nested config take precedence over the inherited ones.
+		own.Licenses = append(own.Licenses, l.deps.Licenses...)
+		own.Excludes = append(own.Excludes, l.deps.Excludes...)
+		nested.deps = own
+	}
+	if err := nested.deps.Finalize(file); err != nil {
+		return nil, err
+	}
+
+	return nested, nil
+}
+
+// nestPatterns returns the comment-styles in the nested config file with the patterns relative to the current directory.
+func nestPatterns(dir string, styles map[string]string) map[string]string {
+	if styles == nil {
+		return nil
+	}
+	nested := make(map[string]string, len(styles))
+	for pattern, id := range styles {
+		nested[nestPath(dir, pattern)] = id
+	}
+	return nested
+}
+
+// nestPath returns the path pattern in the nested config file as relative to the current directory.
+func nestPath(dir, pattern string) string {
+	if pattern == "." || pattern == "./" {
+		return dir + "/"
+	}
+	nested := path.Join(dir, pattern)
+	if strings.HasSuffix(pattern, "/") {
+		nested += "/"
+	}
+	return nested
+}
+
+// child returns the value node of the key in the mapping node, or nil if there isn't such a key.
+func child(node *yaml.Node, key string) *yaml.Node {
+	if i := keyIndex(node, key); i >= 0 {
+		return node.Content[i+1]
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+func TestNestedConfig(t *testing.T) {
+	t.Cleanup(comments.Snapshot())
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	files := map[string]string{
+		".licenserc.yaml": `
+header:
+  license:
+    spdx-id: Apache-2.0
+    copyright-owner: Foo
+  paths-ignore:
+    - '**/*.md'
+    - '.licenserc.yaml'
+    - 'vendor/**'
+dependency:
+  files:
+    - go.mod
+`,
+		"modules/a/.licenserc.yaml": `
+header:
+  license:
+    copyright-owner: Bar
+dependency:
+  files:
+    - go.mod
+  licenses:
+    - name: example.com/x
+      license: MIT
+`,
+		"modules/b/.licenserc.yaml": `
+header:
+  - license:
+      spdx-id: MIT
+    paths:
+      - '**/*.go'
+    comment-styles:
+      'sql/*.txt': DoubleDash
+`,
+		"vendor/c/.licenserc.yaml": `
+header:
+  license:
+    spdx-id: GPL-3.0-only
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	c, err := NewConfigFromFile(".licenserc.yaml")
+	require.NoError(t, err)
+	headers := c.Headers()
+	require.Len(t, headers, 3, "only the nested config in the ignored directory should be ignored, not the ignored config files")
+
+	root, a, b := headers[0], headers[1], headers[2]
+	require.Equal(t, []string{"**/*.md", ".licenserc.yaml", "vendor/**", "modules/a/**", "modules/b/**"}, root.PathsIgnore)
+
+	require.Equal(t, "modules/a", a.Dir)
+	require.Equal(t, "Apache-2.0", a.License.SpdxID)
+	require.Equal(t, "Bar", a.License.CopyrightOwner)
+	require.Equal(t, []string{"modules/a/**"}, a.Paths)
+	require.Equal(t, []string{"**/*.md", ".licenserc.yaml", "vendor/**"}, a.PathsIgnore)
+	ignored, err := a.ShouldIgnore("main.go")
+	require.NoError(t, err)
+	require.True(t, ignored, "the files outside the directory should be ignored by the nested config")
+
+	require.Equal(t, "MIT", b.License.SpdxID)
+	require.Equal(t, "Foo", b.License.CopyrightOwner)
+	require.Equal(t, []string{"modules/b/**/*.go"}, b.Paths)
+	require.Equal(t, map[string]string{"modules/b/sql/*.txt": "DoubleDash"}, b.CommentStyles)
+	require.Nil(t, a.CommentStyles)
+
+	deps := c.Dependencies()
+	require.Len(t, deps.Files, 2)
+	rootMod, _ := filepath.Abs("go.mod")
+	nestedMod, _ := filepath.Abs("modules/a/go.mod")
+	require.Equal(t, []string{rootMod, nestedMod}, deps.Files)
+	require.Same(t, deps, deps.Scope(rootMod))
+	license, ok := deps.Scope(nestedMod).GetUserConfiguredLicense("example.com/x", "v1.0.0")
+	require.True(t, ok)
+	require.Equal(t, "MIT", license)
+}
+
+func TestNestedConfigPaths(t *testing.T) {
+	t.Cleanup(comments.Snapshot())
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	files := map[string]string{
+		".licenserc.yaml": `
+header:
+  license:
+    spdx-id: Apache-2.0
+    copyright-owner: Foo
+  paths-ignore:
+    - '**/*.yaml'
+  preambles:
+    - paths: ['**/*.sh']
+      patterns: ['# shellcheck .*']
+`,
+		"mod/.licenserc.yaml": `
+header:
+  binary-paths:
+    - 'assets/**'
+  preambles:
+    - paths: ['docs/*.md']
+      patterns: ['(?s)---\n.*?\n---\n']
+`,
+		"mod/assets/logo.png": "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR",
+		"assets/logo.png":     "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	c, err := NewConfigFromFile(".licenserc.yaml")
+	require.NoError(t, err)
+	headers := c.Headers()
+	require.Len(t, headers, 2)
+	nested := headers[1]
+	require.Equal(t, []string{"mod/assets/**"}, nested.BinaryPaths)
+	require.Len(t, nested.Preambles, 2)
+	require.Equal(t, []string{"mod/docs/*.md"}, nested.Preambles[0].Paths)
+	require.Equal(t, []string{"**/*.sh"}, nested.Preambles[1].Paths, "the preambles of the parent are inherited")
+
+	var result header.Result
+	for _, h := range headers {
+		for _, file := range []string{"assets/logo.png", "mod/assets/logo.png"} {
+			require.NoError(t, header.CheckFile(file, h, &result))
+		}
+	}
+	require.Equal(t, []string{"mod/assets/logo.png"}, result.Failure, "the binary-paths are relative to the nested directory")
+	require.Equal(t, []string{"assets/logo.png"}, result.Skipped)
+
+	require.NoError(t, os.WriteFile("mod/.licenserc.yaml", []byte("header:\n  language:\n    Foo:\n      extensions: ['.foo']\n      comment_style_id: Hashtag\n"), 0o600))
+	_, err = NewConfigFromFile(".licenserc.yaml")
+	require.ErrorContains(t, err, "language is not supported in nested config files")
+}
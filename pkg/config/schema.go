@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+// schemaID is where the JSON Schema of the config file is published, so that editors can use it.
+const schemaID = "https://raw.githubusercontent.com/apache/skywalking-eyes/main/assets/licenserc.schema.json"
+
+// enums are the allowed values of the string types in the config file.
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(header.CommentOption("")): {string(header.Always), string(header.Never), string(header.OnFailure)},
+	reflect.TypeOf(header.HeaderForm("")):    {string(header.FullForm), string(header.SPDXForm)},
+	reflect.TypeOf(header.YearSource("")):    {string(header.ConfigYear), string(header.GitFirstCommit)},
+	reflect.TypeOf(header.YearPolicy("")):    {string(header.PreserveYear), string(header.ExtendRange), string(header.GitLastModified)},
+	reflect.TypeOf(header.NotebookCell("")):  {string(header.MarkdownCell), string(header.CodeCell)},
+}
+
+// Schema generates the JSON Schema of the config file from the config types, which accepts both V1 and V2.
+func Schema() ([]byte, error) {
+	section := typeSchema(reflect.TypeOf(header.ConfigHeader{}))
+	schema := map[string]any{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$id":     schemaID,
+		"title":   "license-eye configuration",
+		"type":    "object",
+		"properties": map[string]any{
+			"extends": map[string]any{
+				"description": "The base config files to extend, relative to this config file or absolute.",
+				"oneOf":       []any{map[string]any{"type": "string"}, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}},
+			},
+			"header": map[string]any{
+				"description": "The header section (V1), or the list of the header sections (V2).",
+				"oneOf":       []any{section, map[string]any{"type": "array", "items": section}},
+			},
+			"dependency": typeSchema(reflect.TypeOf(V2{}.Deps)),
+		},
+		"additionalProperties": false,
+	}
+	bs, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bs, '\n'), nil
+}
+
+// typeSchema generates the JSON Schema of the type by the yaml tags of its fields.
+func typeSchema(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if values, ok := enums[t]; ok {
+		return map[string]any{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name) // the default key of yaml
+			}
+			properties[name] = typeSchema(field.Type)
+		}
+		return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	}
+	return map[string]any{}
+}
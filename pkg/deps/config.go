@@ -35,6 +35,9 @@ type ConfigDeps struct {
 	Excludes           []Exclude           `yaml:"excludes"`
 	RequireFSFFree     bool                `yaml:"require_fsf_free"`
 	RequireOSIApproved bool                `yaml:"require_osi_approved"`
+
+	// scopes are the configs of the dependency files that come from the nested config files, keyed by the files.
+	scopes map[string]*ConfigDeps
 }
 
 type ConfigDepLicense struct {
@@ -59,7 +62,7 @@ func (config *ConfigDeps) Finalize(configFile string) error {
 	}
 
 	for i, file := range config.Files {
-		if !strings.HasPrefix(file, "/") {
+		if !filepath.IsAbs(file) {
 			config.Files[i] = filepath.Join(filepath.Dir(configFileAbsPath), file)
 		}
 	}
@@ -71,6 +74,25 @@ func (config *ConfigDeps) Finalize(configFile string) error {
 	return nil
 }
 
+// Include adds the dependency files of the nested config, which are resolved with the nested config.
+func (config *ConfigDeps) Include(nested *ConfigDeps) {
+	if config.scopes == nil {
+		config.scopes = make(map[string]*ConfigDeps)
+	}
+	for _, file := range nested.Files {
+		config.Files = append(config.Files, file)
+		config.scopes[file] = nested
+	}
+}
+
+// Scope returns the config that the dependency file is resolved with.
+func (config *ConfigDeps) Scope(file string) *ConfigDeps {
+	if scope, ok := config.scopes[file]; ok {
+		return scope
+	}
+	return config
+}
+
 func (config *ConfigDeps) GetUserConfiguredLicense(name, version string) (string, bool) {
 	for _, license := range config.Licenses {
 		if matched, _ := filepath.Match(license.Name, name); !matched && license.Name != name {
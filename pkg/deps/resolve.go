@@ -43,7 +43,7 @@ resolveFile:
 			if !resolver.CanResolve(file) {
 				continue
 			}
-			if err := resolver.Resolve(file, config, report); err != nil {
+			if err := resolver.Resolve(file, config.Scope(file), report); err != nil {
 				return err
 			}
 			continue resolveFile
@@ -0,0 +1,179 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+// cacheVersion is increased whenever the cache format or the way of checking files changes,
+// so that the caches written by previous versions are discarded.
+const cacheVersion = 2
+
+// Cache remembers the files that passed the check, by their content hashes and the hash of
+// the effective header config that they passed, so that the unchanged files can be skipped
+// in the following checks, until the header config changes.
+type Cache struct {
+	mu   sync.Mutex
+	path string
+	keys sync.Map // *ConfigHeader -> string
+
+	Version int `json:"version"`
+	// Entries maps the config hashes to the files that passed the check.
+	Entries map[string]map[string]cacheEntry `json:"entries"`
+
+	used map[string]bool
+}
+
+type cacheEntry struct {
+	// Hash is the content hash of the file when it passed the check.
+	Hash string `json:"hash"`
+	// License is the accepted license that the file matched.
+	License string `json:"license,omitempty"`
+}
+
+// LoadCache loads the cache from the file, a new cache is returned if the file doesn't exist or is outdated.
+func LoadCache(path string) (*Cache, error) {
+	cache := &Cache{path: path, used: make(map[string]bool)}
+
+	bs, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(bs, cache); err != nil {
+			logger.Log.Warnln("Discarding the invalid cache file:", path, err)
+		}
+	}
+	if cache.Version != cacheVersion || cache.Entries == nil {
+		cache.Version = cacheVersion
+		cache.Entries = make(map[string]map[string]cacheEntry)
+	}
+
+	return cache, nil
+}
+
+// Save writes the cache back to its file, the entries of the header configs unused in this run are dropped.
+func (cache *Cache) Save() error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key := range cache.Entries {
+		if !cache.used[key] {
+			delete(cache.Entries, key)
+		}
+	}
+
+	bs, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(cache.path), filepath.Base(cache.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(bs); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), cache.path)
+}
+
+// Passed tells whether the file with the given content passed the check against the config before,
+// and which accepted license it matched.
+func (cache *Cache) Passed(config *ConfigHeader, file string, content []byte) (license string, passed bool) {
+	key := cache.key(config)
+	if key == "" {
+		return "", false
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.used[key] = true
+	entry, ok := cache.Entries[key][file]
+	if !ok || entry.Hash != contentHash(content) {
+		return "", false
+	}
+	return entry.License, true
+}
+
+// Pass remembers that the file with the given content passed the check against the config, by matching the license.
+func (cache *Cache) Pass(config *ConfigHeader, file string, content []byte, license string) {
+	key := cache.key(config)
+	if key == "" {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.used[key] = true
+	if cache.Entries[key] == nil {
+		cache.Entries[key] = make(map[string]cacheEntry)
+	}
+	cache.Entries[key][file] = cacheEntry{Hash: contentHash(content), License: license}
+}
+
+// key returns the hash of the parts of the config that decide whether a file passes the check,
+// the license content, pattern, location threshold, comment styles, etc.
+func (cache *Cache) key(config *ConfigHeader) string {
+	if key, ok := cache.keys.Load(config); ok {
+		return key.(string)
+	}
+
+	c := *config
+	// These don't decide whether a given file passes the check but which files to check.
+	c.Paths, c.PathsIgnore, c.Comment, c.Since, c.Cache, c.DryRun = nil, nil, "", "", nil, nil
+
+	var licenses []string
+	for _, alternative := range config.alternatives() {
+		licenses = append(licenses, alternative.GetLicenseContent())
+	}
+	bs, err := json.Marshal(struct {
+		Config   ConfigHeader
+		Licenses []string
+	}{c, licenses})
+	if err != nil {
+		// Should never happen, an empty key disables the cache for the config.
+		logger.Log.Warnln("Failed to compute the cache key of the header config:", err)
+		return ""
+	}
+
+	key := contentHash(bs)
+	cache.keys.Store(config, key)
+	return key
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".license-eye-cache")
+	content := []byte("// Apache License 2.0\npackage main\n")
+
+	cache, err := LoadCache(path)
+	require.NoError(t, err)
+	passed := func(config *ConfigHeader, file string, content []byte) bool {
+		_, ok := cache.Passed(config, file, content)
+		return ok
+	}
+
+	config := &ConfigHeader{License: LicenseConfig{Content: "Apache License 2.0"}, LicenseLocationThreshold: 80}
+	require.False(t, passed(config, "main.go", content))
+
+	cache.Pass(config, "main.go", content, "license")
+	require.True(t, passed(config, "main.go", content))
+	require.False(t, passed(config, "main.go", []byte("package main\n")), "changed content should not pass")
+	require.False(t, passed(config, "other.go", content), "other files should not pass")
+
+	require.NoError(t, cache.Save())
+
+	cache, err = LoadCache(path)
+	require.NoError(t, err)
+	license, ok := cache.Passed(config, "main.go", content)
+	require.True(t, ok, "the cache should be persistent")
+	require.Equal(t, "license", license)
+
+	for _, changed := range []*ConfigHeader{
+		{License: LicenseConfig{Content: "MIT License"}, LicenseLocationThreshold: 80},
+		{License: LicenseConfig{Content: "Apache License 2.0", Pattern: "Apache"}, LicenseLocationThreshold: 80},
+		{License: LicenseConfig{Content: "Apache License 2.0"}, LicenseLocationThreshold: 100},
+		{License: LicenseConfig{Content: "Apache License 2.0"}, Licenses: []LicenseConfig{{Content: "MIT License"}}, LicenseLocationThreshold: 80},
+	} {
+		require.False(t, passed(changed, "main.go", content), "the cache should be invalidated when the config changes")
+	}
+
+	// Paths to check don't decide whether a file passes the check.
+	withPaths := *config
+	withPaths.Paths = []string{"main.go"}
+	require.True(t, passed(&withPaths, "main.go", content))
+}
+
+func TestCacheSaveDropsUnusedConfigs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".license-eye-cache")
+	content := []byte("package main\n")
+	oldConfig := &ConfigHeader{License: LicenseConfig{Content: "old"}}
+	newConfig := &ConfigHeader{License: LicenseConfig{Content: "new"}}
+
+	cache, err := LoadCache(path)
+	require.NoError(t, err)
+	cache.Pass(oldConfig, "main.go", content, "")
+	require.NoError(t, cache.Save())
+
+	cache, err = LoadCache(path)
+	require.NoError(t, err)
+	cache.Pass(newConfig, "main.go", content, "")
+	require.NoError(t, cache.Save())
+
+	cache, err = LoadCache(path)
+	require.NoError(t, err)
+	require.Len(t, cache.Entries, 1)
+	_, ok := cache.Passed(newConfig, "main.go", content)
+	require.True(t, ok)
+}
@@ -19,12 +19,14 @@ package header
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	eyeignore "github.com/apache/skywalking-eyes/pkg/gitignore"
@@ -72,6 +74,9 @@ func listFiles(config *ConfigHeader) ([]string, error) {
 	repo, err := git.PlainOpen(currentDir)
 
 	if err != nil { // we're not in a Git workspace, fallback to glob paths
+		if config.Since != "" {
+			return nil, fmt.Errorf("cannot check the files changed since %q, not in a git repository: %w", config.Since, err)
+		}
 		var localFileList []string
 		for _, pattern := range config.Paths {
 			if pattern == "." {
@@ -105,35 +110,16 @@ func listFiles(config *ConfigHeader) ([]string, error) {
 			candidates = append(candidates, file)
 		}
 
-		head, err := repo.Head()
-		if err != nil || head == nil {
-			// Repository has no commits or invalid HEAD, skip git-based file discovery
-			logger.Log.Debugf("Repository has no commits or invalid HEAD (head: %v), skipping git-based file discovery. Error: %v", head, err)
+		var tracked []string
+		if config.Since != "" {
+			tracked, err = changedFiles(repo, config.Since)
 		} else {
-			commit, err := repo.CommitObject(head.Hash())
-			if err != nil {
-				logger.Log.Debugln("Failed to get commit object:", err)
-			} else {
-				tree, err := commit.Tree()
-				if err != nil {
-					return nil, err
-				}
-				if err := tree.Files().ForEach(func(file *object.File) error {
-					if file == nil {
-						return errors.New("file pointer is nil")
-					}
-					candidates = append(candidates, file.Name)
-					return nil
-				}); err != nil {
-					if errors.Is(err, plumbing.ErrObjectNotFound) {
-						return nil, errors.New(
-							"failed to read git repository. Run 'git fsck' to diagnose. If dangling objects are found, run: git prune && git gc --prune=now --aggressive",
-						)
-					}
-					return nil, err
-				}
-			}
+			tracked, err = headFiles(repo)
+		}
+		if err != nil {
+			return nil, err
 		}
+		candidates = append(candidates, tracked...)
 
 		seen := make(map[string]bool)
 		for _, candidate := range candidates {
@@ -152,7 +138,46 @@ func listFiles(config *ConfigHeader) ([]string, error) {
 		}
 	}
 
-	return fileList, nil
+	return slices.DeleteFunc(fileList, func(file string) bool {
+		return !config.Covers(file)
+	}), nil
+}
+
+// headFiles returns the files in the tree of HEAD, or nothing if the repository has no valid HEAD.
+func headFiles(repo *git.Repository) ([]string, error) {
+	var files []string
+
+	head, err := repo.Head()
+	if err != nil || head == nil {
+		// Repository has no commits or invalid HEAD, skip git-based file discovery
+		logger.Log.Debugf("Repository has no commits or invalid HEAD (head: %v), skipping git-based file discovery. Error: %v", head, err)
+		return nil, nil
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		logger.Log.Debugln("Failed to get commit object:", err)
+		return nil, nil
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.Files().ForEach(func(file *object.File) error {
+		if file == nil {
+			return errors.New("file pointer is nil")
+		}
+		files = append(files, file.Name)
+		return nil
+	}); err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil, errors.New(
+				"failed to read git repository. Run 'git fsck' to diagnose. If dangling objects are found, run: git prune && git gc --prune=now --aggressive",
+			)
+		}
+		return nil, err
+	}
+
+	return files, nil
 }
 
 func MatchPaths(file string, patterns []string) bool {
@@ -248,23 +273,85 @@ func CheckFile(file string, config *ConfigHeader, result *Result) error {
 		result.Ignore(file)
 		return err
 	}
+	if config.SkipGenerated {
+		if generated, err := config.isGenerated(file); generated || err != nil {
+			logger.Log.Debugln("Ignoring generated file:", file)
+			result.IgnoreWithReason(file, Generated)
+			return err
+		}
+	}
 
 	logger.Log.Debugln("Checking file:", file)
 
+	required, err := config.requiresLicensingInfo(file)
+	if err != nil {
+		return err
+	}
+	if required {
+		if done, err := checkREUSE(file, config, result); done || err != nil {
+			return err
+		}
+	}
+
+	if config.MaxFileSize > 0 {
+		stat, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		if stat.Size() > config.MaxFileSize {
+			logger.Log.Debugln("Skipping file:", file, "; size:", stat.Size())
+			result.Skip(file, TooLarge)
+			return nil
+		}
+	}
+
 	bs, err := os.ReadFile(file)
 	if err != nil {
 		return err
 	}
-	if t := http.DetectContentType(bs); !strings.HasPrefix(t, "text/") {
-		logger.Log.Debugln("Ignoring file:", file, "; type:", t)
+	text, _ := decode(bs)
+	if t := http.DetectContentType(text); !strings.HasPrefix(t, "text/") {
+		if required {
+			logger.Log.Debugln("Non-text file without a sidecar file:", file, "; type:", t)
+			result.Fail(file)
+			return nil
+		}
+		logger.Log.Debugln("Skipping file:", file, "; type:", t)
+		result.Skip(file, BinaryFile)
 		return nil
 	}
 
-	content := lcs.NormalizeHeader(string(bs))
-	expected, pattern := config.NormalizedLicense(), config.NormalizedPattern()
+	if config.Cache != nil {
+		if license, passed := config.Cache.Passed(config, file, bs); passed {
+			logger.Log.Debugln("File passed the check before and is unchanged:", file)
+			result.SucceedWith(file, license)
+			return nil
+		}
+	}
 
-	if satisfy(content, config, expected, pattern) {
-		result.Succeed(file)
+	if text, err = config.headerRegion(file, text); err != nil {
+		return err
+	}
+
+	content := lcs.NormalizeHeader(string(text))
+
+	staleYear := false
+	for i, alternative := range config.alternatives() {
+		switch found, upToDate := checkYears(file, content, alternative); {
+		case found && !upToDate:
+			staleYear = true
+		case found || matches(string(text), content, alternative, alternative.FileContext(file)):
+			license := config.licenseName(i)
+			if config.Cache != nil {
+				config.Cache.Pass(config, file, bs, license)
+			}
+			result.SucceedWith(file, license)
+			return nil
+		}
+	}
+
+	if staleYear {
+		result.FailWithReason(file, StaleYear)
 	} else {
 		logger.Log.Debugln("Content is:", content)
 
@@ -274,6 +361,25 @@ func CheckFile(file string, config *ConfigHeader, result *Result) error {
 	return nil
 }
 
+// headerRegion returns the part of the file content where the license header is looked for, that is the first cell
+// of a notebook, and the content after the preamble, as the license-location-threshold is measured from there.
+func (config *ConfigHeader) headerRegion(file string, text []byte) ([]byte, error) {
+	if isNotebook(file) {
+		if cell, ok := firstCell(text); ok {
+			text = []byte(cell)
+		}
+	}
+	return config.trimPreamble(file, text)
+}
+
+// matches tells whether the raw content, whose normalized form is content, has the license header of the config.
+func matches(raw, content string, config *ConfigHeader, ctx *FileContext) bool {
+	if config.License.Form == SPDXForm {
+		return checkSPDX(raw, config) == nil
+	}
+	return satisfy(content, config, lcs.Normalize(config.LicenseContent(ctx)), config.NormalizedPattern())
+}
+
 func satisfy(content string, config *ConfigHeader, license string, pattern *regexp.Regexp) bool {
 	if index := strings.Index(content, license); strings.TrimSpace(license) != "" && index >= 0 {
 		return index < config.LicenseLocationThreshold
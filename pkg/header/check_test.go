@@ -18,6 +18,7 @@
 package header
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -274,6 +275,48 @@ func TestListFilesWithWorktreeDetachedHEAD(t *testing.T) {
 	}
 }
 
+func TestListFilesSince(t *testing.T) {
+	tempDir := t.TempDir()
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(tempDir))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(message string, files ...string) plumbing.Hash {
+		for _, file := range files {
+			require.NoError(t, os.WriteFile(file, []byte("// "+message+"\npackage main"), 0o600))
+			_, err := worktree.Add(file)
+			require.NoError(t, err)
+		}
+		hash, err := worktree.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com"},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+
+	base := commit("base", "old.go", "modified.go")
+	commit("change", "new.go", "modified.go")
+	require.NoError(t, os.WriteFile("untracked.go", []byte("package main"), 0o600))
+
+	config := &ConfigHeader{Paths: []string{"**/*.go"}, Since: base.String()}
+	fileList, err := listFiles(config)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"new.go", "modified.go", "untracked.go"}, fileList)
+
+	config.Since = "no-such-revision"
+	_, err = listFiles(config)
+	require.Error(t, err)
+}
+
 func TestMatchPaths(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -346,3 +389,77 @@ func TestMatchPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckFileWithAcceptedLicenses(t *testing.T) {
+	config := &ConfigHeader{
+		Licenses: []LicenseConfig{
+			{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"},
+			{SpdxID: "MIT", CopyrightOwner: "Bar", CopyrightYear: "2026"},
+		},
+	}
+	require.NoError(t, config.Finalize())
+	require.Equal(t, "Apache-2.0", config.License.SpdxID, "the first accepted license should be used by fix")
+
+	dir := t.TempDir()
+	write := func(name string, c *ConfigHeader) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(getLicenseHeaderCustomConfig(name, t.Error, c)+"package main\n"), 0o600))
+		return file
+	}
+	mit := *config
+	mit.License, mit.Licenses = config.Licenses[0], nil
+	gpl := &ConfigHeader{License: LicenseConfig{SpdxID: "GPL-3.0-or-later", CopyrightOwner: "Foo", CopyrightYear: "2026"}}
+	apacheFile, mitFile, gplFile := write("apache.go", config), write("mit.go", &mit), write("gpl.go", gpl)
+
+	var result Result
+	for _, file := range []string{apacheFile, mitFile, gplFile} {
+		require.NoError(t, CheckFile(file, config, &result))
+	}
+	require.Equal(t, []string{apacheFile, mitFile}, result.Success)
+	require.Equal(t, []string{gplFile}, result.Failure)
+	require.Equal(t, "Apache-2.0", result.License(apacheFile))
+	require.Equal(t, "MIT", result.License(mitFile))
+}
+
+func TestCheckFileSkipped(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	files := map[string][]byte{
+		"logo.png":                  png,
+		"assets/icon.png":           png,
+		"assets/banner.png":         png,
+		"assets/banner.png.license": []byte("SPDX-FileCopyrightText: 2026 Foo\nSPDX-License-Identifier: Apache-2.0\n"),
+		"large.go":                  bytes.Repeat([]byte("// comment\n"), 100),
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, content, 0o600))
+	}
+
+	config := &ConfigHeader{
+		License:     LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo"},
+		BinaryPaths: []string{"assets/**"},
+		MaxFileSize: 1000,
+	}
+	require.NoError(t, config.Finalize())
+
+	result := &Result{}
+	for _, file := range []string{"logo.png", "assets/icon.png", "assets/banner.png", "large.go"} {
+		require.NoError(t, CheckFile(file, config, result))
+	}
+	require.Equal(t, []string{"assets/banner.png"}, result.Success)
+	require.Equal(t, []string{"assets/icon.png"}, result.Failure, "the binary paths must have licensing information")
+	require.Equal(t, []string{"logo.png", "large.go"}, result.Skipped)
+	require.Equal(t, BinaryFile, result.SkipReason("logo.png"))
+	require.Equal(t, TooLarge, result.SkipReason("large.go"))
+	require.Contains(t, result.String(), "Totally checked 4 files, valid: 1, invalid: 1, ignored: 0, fixed: 0, skipped: 2")
+
+	require.NoError(t, Fix("assets/icon.png", config, result))
+	require.FileExists(t, "assets/icon.png.license", "fix should create the sidecar file for the binary paths")
+}
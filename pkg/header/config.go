@@ -18,9 +18,13 @@
 package header
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -31,6 +35,7 @@ import (
 	"github.com/apache/skywalking-eyes/pkg/logger"
 
 	"github.com/bmatcuk/doublestar/v2"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
 )
 
 type CommentOption string
@@ -47,21 +52,79 @@ type LicenseConfig struct {
 	SpdxID         string `yaml:"spdx-id"`
 	CopyrightOwner string `yaml:"copyright-owner"`
 	CopyrightYear  string `yaml:"copyright-year"`
-	SoftwareName   string `yaml:"software-name"`
-	Content        string `yaml:"content"`
-	Pattern        string `yaml:"pattern"`
+	// CopyrightYearSource decides where the "[year]" placeholder of each file is resolved from.
+	CopyrightYearSource YearSource `yaml:"copyright-year-source"`
+	// CopyrightYearPolicy decides how the copyright years in the existing license headers are maintained.
+	CopyrightYearPolicy YearPolicy `yaml:"copyright-year-policy"`
+	SoftwareName        string     `yaml:"software-name"`
+	Content             string     `yaml:"content"`
+	Pattern             string     `yaml:"pattern"`
+	// Form is the form of the license headers, the full license notice text or the SPDX short-form identifiers.
+	Form HeaderForm `yaml:"form"`
+}
+
+// name identifies the license in the results, which is the spdx-id, or the given fallback if there isn't one.
+func (license *LicenseConfig) name(fallback string) string {
+	if license.SpdxID != "" {
+		return license.SpdxID
+	}
+	return fallback
 }
 
 type ConfigHeader struct {
-	License     LicenseConfig `yaml:"license"`
-	Paths       []string      `yaml:"paths"`
-	PathsIgnore []string      `yaml:"paths-ignore"`
-	Comment     CommentOption `yaml:"comment"`
+	// License is the license that the files should have, and the one that fix inserts.
+	License LicenseConfig `yaml:"license"`
+	// Licenses are the other accepted licenses, a file passes the check if it has any of them or the License.
+	Licenses    []LicenseConfig `yaml:"licenses"`
+	Paths       []string        `yaml:"paths"`
+	PathsIgnore []string        `yaml:"paths-ignore"`
+	Comment     CommentOption   `yaml:"comment"`
 
 	// LicenseLocationThreshold specifies the index threshold where the license header can be located,
 	// after all, a "header" cannot be TOO far from the file start.
 	LicenseLocationThreshold int                          `yaml:"license-location-threshold"`
 	Languages                map[string]comments.Language `yaml:"language"`
+	// CustomCommentStyles are the comment styles declared in the config file, in the same form as the built-in ones,
+	// which can be used by the Languages and the CommentStyles.
+	CustomCommentStyles []comments.CommentStyle `yaml:"custom-comment-styles"`
+	// Preambles are the rules of the content at the start of the files that the license header is placed after.
+	Preambles []PreambleRule `yaml:"preambles"`
+	// CommentStyles are the comment_style_id of the files matching the doublestar patterns, keyed by the patterns,
+	// they take precedence over the comment styles of the languages of the files.
+	CommentStyles map[string]string `yaml:"comment-styles"`
+	// REUSE enables the REUSE compliance mode, where the files can also have their licenses in the sidecar
+	// files ("<file>.license") and the REUSE.toml (or the legacy .reuse/dep5), see https://reuse.software/spec/.
+	REUSE bool `yaml:"reuse"`
+	// BinaryPaths are the paths/patterns of the non-text files that must have their licenses in the sidecar files
+	// or the REUSE.toml (.reuse/dep5), the other non-text files are skipped unless it's in the REUSE mode.
+	BinaryPaths []string `yaml:"binary-paths"`
+	// MaxFileSize is the max size in bytes of the files to check, the larger ones are skipped, 0 means no limit.
+	MaxFileSize int64 `yaml:"max-file-size"`
+
+	// NotebookCell is the type of the cell that the license header is inserted in the Jupyter notebooks.
+	NotebookCell NotebookCell `yaml:"notebook-cell"`
+	// SkipGenerated enables skipping the generated files, which are marked by the well-known markers,
+	// or the linguist-generated attribute in the .gitattributes files, they are reported as ignored.
+	SkipGenerated bool `yaml:"skip-generated"`
+
+	reuse         *reuseInfo
+	gitAttributes []gitattributes.MatchAttribute
+	history       *gitHistory
+
+	// Dir, when it's set, is the directory of the nested config file that the header section comes from,
+	// and only the files under it are checked. It's set when loading the nested config files.
+	Dir string `yaml:"-"`
+
+	// Since is a git revision, when it's set, only the files changed since the merge base of
+	// the revision and HEAD, and the files changed in the worktree, are checked.
+	// It's set from the command line instead of the config file.
+	Since string `yaml:"-"`
+	// Cache, when it's set, is used to skip the unchanged files that passed the check before.
+	// It's set from the command line instead of the config file.
+	Cache *Cache `yaml:"-"`
+	// DryRun, when it's set, collects the changes of the fix into the patch instead of writing them to the files.
+	// It's set from the command line instead of the config file.
+	DryRun *Patch `yaml:"-"`
 }
 
 // NormalizedLicense returns the normalized string of the license content,
@@ -115,7 +178,20 @@ func (config *ConfigHeader) NormalizedPattern() *regexp.Regexp {
 	return regexp.MustCompile("(?i).*" + pattern + ".*")
 }
 
+// Covers tells whether the file is under the Dir of the header section, if it's set.
+func (config *ConfigHeader) Covers(path string) bool {
+	if config.Dir == "" {
+		return true
+	}
+	path = filepath.ToSlash(filepath.Clean(path))
+	return strings.HasPrefix(path, config.Dir+"/")
+}
+
 func (config *ConfigHeader) ShouldIgnore(path string) (bool, error) {
+	if !config.Covers(path) {
+		return true, nil
+	}
+
 	matched, err := tryMatchPatten(path, config.Paths)
 	if !matched || err != nil {
 		return !matched, err
@@ -129,6 +205,45 @@ func (config *ConfigHeader) ShouldIgnore(path string) (bool, error) {
 	return false, nil
 }
 
+// requiresLicensingInfo tells whether the file must have its licensing information even if it's not a text file,
+// in the sidecar file or the REUSE.toml (.reuse/dep5).
+func (config *ConfigHeader) requiresLicensingInfo(file string) (bool, error) {
+	if config.REUSE {
+		return true, nil
+	}
+	return tryMatchPatten(file, config.BinaryPaths)
+}
+
+// validatePattern validates the syntax of the doublestar glob pattern, which is only reported by doublestar
+// when a path is matched against the malformed part of it.
+func validatePattern(pattern string) error {
+	braces := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if i++; i == len(pattern) {
+				return errors.New("dangling escape")
+			}
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 || (end == 0 || end == 1 && strings.ContainsRune("^!", rune(pattern[i+1]))) {
+				return errors.New("unterminated or empty character class")
+			}
+			i += end + 1
+		case '{':
+			braces++
+		case '}':
+			if braces--; braces < 0 {
+				return errors.New("unmatched '}'")
+			}
+		}
+	}
+	if braces > 0 {
+		return errors.New("unterminated '{'")
+	}
+	return nil
+}
+
 func tryMatchPatten(path string, patterns []string) (bool, error) {
 	for _, pattern := range patterns {
 		if m, err := doublestar.Match(pattern, path); m || err != nil {
@@ -157,7 +272,70 @@ func (config *ConfigHeader) Finalize() error {
 		config.Paths = []string{"**"}
 	}
 
+	if config.REUSE || len(config.BinaryPaths) > 0 {
+		reuse, err := loadREUSE(currentDir)
+		if err != nil {
+			return err
+		}
+		config.reuse = reuse
+	}
+
+	if config.SkipGenerated {
+		attributes, err := loadGitAttributes(currentDir)
+		if err != nil {
+			return err
+		}
+		config.gitAttributes = attributes
+	}
+
+	// the git history is walked on the first use, and shared by the alternatives
+	config.history = new(gitHistory)
+
+	if config.License == (LicenseConfig{}) && len(config.Licenses) > 0 {
+		config.License, config.Licenses = config.Licenses[0], config.Licenses[1:]
+	}
+
+	if err := comments.DeclareCommentStyles(config.CustomCommentStyles); err != nil {
+		return err
+	}
+	for _, style := range config.CustomCommentStyles {
+		license.AddCommentIndicators(style.Start, style.Middle, style.End)
+	}
+	for name, language := range config.Languages {
+		if id := language.CommentStyleID; id != "" && !comments.StyleExists(id) {
+			return fmt.Errorf("unknown comment_style_id %q of the language %q", id, name)
+		}
+	}
+	for pattern, id := range config.CommentStyles {
+		if !comments.StyleExists(id) {
+			return fmt.Errorf("unknown comment_style_id %q of the pattern %q in comment-styles", id, pattern)
+		}
+	}
+	for field, patterns := range map[string][]string{
+		"paths":          config.Paths,
+		"paths-ignore":   config.PathsIgnore,
+		"binary-paths":   config.BinaryPaths,
+		"comment-styles": slices.Sorted(maps.Keys(config.CommentStyles)),
+	} {
+		for _, pattern := range patterns {
+			if err := validatePattern(pattern); err != nil {
+				return fmt.Errorf("invalid pattern %q in %v: %w", pattern, field, err)
+			}
+		}
+	}
+
 	comments.OverrideLanguageCommentStyle(config.Languages)
+	comments.OverrideCommentStyles(config.CommentStyles)
+
+	if err := config.NotebookCell.validate(); err != nil {
+		return err
+	}
+
+	for i := range config.Preambles {
+		if err := config.Preambles[i].compile(); err != nil {
+			return fmt.Errorf("invalid preambles[%d]: %w", i, err)
+		}
+	}
 
 	logger.Log.Debugln("License header is:", config.NormalizedLicense())
 
@@ -169,14 +347,96 @@ func (config *ConfigHeader) Finalize() error {
 		config.LicenseLocationThreshold = 80
 	}
 
+	for _, alternative := range config.alternatives() {
+		if err := alternative.License.Form.validate(); err != nil {
+			return err
+		}
+		if alternative.License.Form == SPDXForm {
+			if _, err := parseSPDX(alternative.License.SpdxID); err != nil {
+				return fmt.Errorf("the spdx-id is required to be a valid SPDX license expression in the %q form: %w", SPDXForm, err)
+			}
+		}
+		if err := alternative.License.CopyrightYearSource.validate(); err != nil {
+			return err
+		}
+		if err := alternative.License.CopyrightYearPolicy.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (config *ConfigHeader) GetLicenseContent() (c string) {
-	owner, name, year := config.License.CopyrightOwner, config.License.SoftwareName, config.License.CopyrightYear
+// alternatives returns the configs of all the accepted licenses, each with one of the accepted licenses
+// as the License, the first one is the config itself.
+func (config *ConfigHeader) alternatives() []*ConfigHeader {
+	alternatives := []*ConfigHeader{config}
+	for _, license := range config.Licenses {
+		alternative := *config
+		alternative.License, alternative.Licenses = license, nil
+		alternatives = append(alternatives, &alternative)
+	}
+	return alternatives
+}
+
+// licenseName identifies the license of the i-th alternative config in the results.
+func (config *ConfigHeader) licenseName(i int) string {
+	if i == 0 {
+		return config.License.name("license")
+	}
+	return config.Licenses[i-1].name(fmt.Sprintf("licenses[%d]", i-1))
+}
+
+// FileContext is the information of a single file that its license header depends on.
+type FileContext struct {
+	// Path is the path of the file.
+	Path string
+	// Year is the copyright years of the file, which replace the "[year]" placeholder in the license content.
+	Year string
+}
+
+// FileContext resolves the information of the file that its license header depends on.
+func (config *ConfigHeader) FileContext(file string) *FileContext {
+	return &FileContext{Path: file, Year: config.copyrightYears(file)}
+}
+
+// copyrightYears returns the copyright years of the file, or of all the files if file is empty.
+func (config *ConfigHeader) copyrightYears(file string) string {
+	year := config.License.CopyrightYear
+	if file != "" && config.License.CopyrightYearSource == GitFirstCommit && config.hasYears() {
+		if first, err := config.history.firstCommitYear(file); err != nil {
+			logger.Log.Debugln("Failed to find the first commit year of", file, err)
+		} else {
+			year = strconv.Itoa(first)
+		}
+	}
 	if year == "" {
 		year = strconv.Itoa(time.Now().Year())
 	}
+	if config.License.CopyrightYearPolicy.maintainsYears() {
+		year = extendYears(year, time.Now().Year())
+	}
+	return year
+}
+
+// hasYears tells whether the license content has the "[year]" placeholder, which the copyright years replace.
+func (config *ConfigHeader) hasYears() bool {
+	return strings.Contains(config.licenseContent(yearPlaceholder), yearPlaceholder)
+}
+
+// GetLicenseContent returns the license content shared by all the files, use LicenseContent
+// to get the one of a specific file, as the "[year]" placeholder may be resolved per file.
+func (config *ConfigHeader) GetLicenseContent() string {
+	return config.licenseContent(config.copyrightYears(""))
+}
+
+// LicenseContent returns the license content of the file.
+func (config *ConfigHeader) LicenseContent(ctx *FileContext) string {
+	return config.licenseContent(ctx.Year)
+}
+
+// licenseContent returns the license content where the "[year]" placeholder is replaced with the given years.
+func (config *ConfigHeader) licenseContent(year string) (c string) {
+	owner, name := config.License.CopyrightOwner, config.License.SoftwareName
 
 	defer func() {
 		c = strings.ReplaceAll(c, "[year]", year)
@@ -184,6 +444,9 @@ func (config *ConfigHeader) GetLicenseContent() (c string) {
 		c = strings.ReplaceAll(c, "[software-name]", name)
 	}()
 
+	if config.License.Form == SPDXForm {
+		return spdxContent(&config.License)
+	}
 	if c = strings.TrimSpace(config.License.Content); c != "" {
 		return config.License.Content // Do not change anything in user config
 	}
@@ -20,8 +20,14 @@ package header
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	"github.com/apache/skywalking-eyes/pkg/license"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetLicenseContent(t *testing.T) {
@@ -110,3 +116,85 @@ See the Mulan PSL v2 for more details.
 		}
 	}
 }
+
+func TestFinalizeValidation(t *testing.T) {
+	t.Cleanup(comments.Snapshot())
+
+	for _, test := range []struct {
+		config ConfigHeader
+		err    string
+	}{
+		{
+			config: ConfigHeader{Languages: map[string]comments.Language{"Foo": {Extensions: []string{".foo"}, CommentStyleID: "NoSuchStyle"}}},
+			err:    `unknown comment_style_id "NoSuchStyle" of the language "Foo"`,
+		},
+		{config: ConfigHeader{PathsIgnore: []string{"src/[abc"}}, err: `invalid pattern "src/[abc" in paths-ignore`},
+		{config: ConfigHeader{Paths: []string{"src/{a,b"}}, err: `invalid pattern "src/{a,b" in paths`},
+		{config: ConfigHeader{BinaryPaths: []string{`assets\`}}, err: `invalid pattern "assets\\" in binary-paths`},
+		{
+			config: ConfigHeader{CommentStyles: map[string]string{"sql/**/*.txt": "NoSuchStyle"}},
+			err:    `unknown comment_style_id "NoSuchStyle" of the pattern "sql/**/*.txt" in comment-styles`,
+		},
+		{config: ConfigHeader{CommentStyles: map[string]string{"sql/[": "DoubleDash"}}, err: `invalid pattern "sql/[" in comment-styles`},
+		{
+			config: ConfigHeader{CustomCommentStyles: []comments.CommentStyle{{ID: "Hashtag", Start: "#", Middle: "#", End: "#"}}},
+			err:    `the comment style "Hashtag" is built-in`,
+		},
+		{config: ConfigHeader{CustomCommentStyles: []comments.CommentStyle{{ID: "Empty"}}}, err: `comment style 'start' cannot be empty`},
+		{
+			config: ConfigHeader{Preambles: []PreambleRule{{Languages: []string{"NoSuchLanguage"}, Patterns: []string{`x`}}}},
+			err:    `invalid preambles[0]: unknown language "NoSuchLanguage"`,
+		},
+		{
+			config: ConfigHeader{Preambles: []PreambleRule{{Paths: []string{"**/*.md"}, Patterns: []string{`(---`}}}},
+			err:    `invalid preambles[0]: invalid pattern "(---" in patterns`,
+		},
+		{config: ConfigHeader{Preambles: []PreambleRule{{Patterns: []string{`x`}}}}, err: `either languages or paths is required`},
+		{
+			config: ConfigHeader{CustomCommentStyles: []comments.CommentStyle{{ID: "BadAfter", Start: ";;", After: "(("}}},
+			err:    `comment style 'after' is not a valid regular expression`,
+		},
+		{config: ConfigHeader{NotebookCell: "raw"}, err: `unsupported notebook cell "raw"`},
+	} {
+		test.config.License.SpdxID = "Apache-2.0"
+		require.ErrorContains(t, test.config.Finalize(), test.err)
+	}
+
+	valid := ConfigHeader{
+		License:     LicenseConfig{SpdxID: "Apache-2.0"},
+		Paths:       []string{"**/*.{go,java}", "src/[a-z]*/**", `file\[1\].go`},
+		PathsIgnore: []string{"**/*.md"},
+		Languages:   map[string]comments.Language{"Foo": {Extensions: []string{".foo"}, CommentStyleID: "DoubleSlash"}},
+	}
+	require.NoError(t, valid.Finalize())
+}
+
+func TestCustomCommentStyles(t *testing.T) {
+	t.Cleanup(comments.Snapshot())
+
+	config := ConfigHeader{
+		License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo"},
+		CustomCommentStyles: []comments.CommentStyle{
+			{ID: "RemDash", Start: "REM --", Middle: "REM --", End: "REM --"},
+		},
+		Languages:     map[string]comments.Language{"Batch DSL": {Extensions: []string{".bdsl"}, CommentStyleID: "RemDash"}},
+		CommentStyles: map[string]string{"dsl/**": "RemDash"},
+	}
+	require.NoError(t, config.Finalize())
+	require.NoError(t, config.Finalize(), "declaring the same comment styles again should be fine")
+
+	for _, file := range []string{"build.bdsl", "dsl/build"} {
+		style := comments.FileCommentStyle(file)
+		require.NotNil(t, style, file)
+		require.Equal(t, "RemDash", style.ID, file)
+
+		h, err := GenerateLicenseHeader(style, &config, config.FileContext(file))
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(h, "REM -- Copyright"), h)
+		require.Contains(t, license.Normalize(h), config.NormalizedLicense(), "the generated header should pass the check")
+	}
+
+	redeclared := config
+	redeclared.CustomCommentStyles = []comments.CommentStyle{{ID: "RemDash", Start: "REM"}}
+	require.ErrorContains(t, redeclared.Finalize(), `the comment style "RemDash" is declared differently`)
+}
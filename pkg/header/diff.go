@@ -38,8 +38,25 @@ import (
 // but missing in the file, and {+text+} marks text that is in the file but not
 // expected by the configured license. An empty diff is returned when the file's
 // license header is valid.
+//
+// For the SPDX short-form license headers, the explanation of why the check fails is returned instead.
 func DiffFile(file string, config *ConfigHeader) (string, error) {
-	expected := config.NormalizedLicense()
+	if config.License.Form == SPDXForm {
+		bs, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		text, _ := decode(bs)
+		if text, err = config.headerRegion(file, text); err != nil {
+			return "", err
+		}
+		if err := checkSPDX(string(text), config); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}
+
+	expected := lcs.Normalize(config.LicenseContent(config.FileContext(file)))
 	if expected == "" {
 		return "", fmt.Errorf("no license content configured (spdx-id or content) to diff against")
 	}
@@ -48,11 +65,16 @@ func DiffFile(file string, config *ConfigHeader) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if t := http.DetectContentType(bs); !strings.HasPrefix(t, "text/") {
+	text, _ := decode(bs)
+	if t := http.DetectContentType(text); !strings.HasPrefix(t, "text/") {
 		return "", fmt.Errorf("not a text file: %v (%v)", file, t)
 	}
 
-	content := lcs.NormalizeHeader(string(bs))
+	if text, err = config.headerRegion(file, text); err != nil {
+		return "", err
+	}
+
+	content := lcs.NormalizeHeader(string(text))
 	if satisfy(content, config, expected, config.NormalizedPattern()) {
 		return "", nil
 	}
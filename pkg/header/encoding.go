@@ -0,0 +1,170 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"unicode/utf16"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+	crlf       = []byte("\r\n")
+)
+
+// sniffSize is the size of the head of a file to tell whether it's in UTF-16 without a BOM.
+const sniffSize = 512
+
+// encoding is how the content of a file is encoded, which is kept when the file is rewritten.
+type encoding struct {
+	// bom is the byte order mark at the start of the file, if there is one.
+	bom []byte
+	// utf16 is the byte order of the file if it's in UTF-16, or nil if it's not.
+	utf16 binary.ByteOrder
+	// crlf tells whether the new lines end with CRLF, judged by the first line of the file.
+	crlf bool
+	// text is the decoded text if the file has CRLF line endings, and crs are the offsets of the line breaks
+	// in it that are CRLF in the file, by which the line endings of the unchanged content are kept as they are.
+	text []byte
+	crs  []int
+}
+
+// decode decodes the content of a file into UTF-8 text with LF line endings and without the BOM,
+// which is what the header commands work on, and returns the encoding to encode the text back.
+// The files with mixed line endings are supported, the line ending of each line is kept.
+func decode(content []byte) ([]byte, encoding) {
+	var enc encoding
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		enc.bom = utf8BOM
+	case bytes.HasPrefix(content, utf16LEBOM) && len(content)%2 == 0:
+		enc.bom, enc.utf16 = utf16LEBOM, binary.LittleEndian
+	case bytes.HasPrefix(content, utf16BEBOM) && len(content)%2 == 0:
+		enc.bom, enc.utf16 = utf16BEBOM, binary.BigEndian
+	default:
+		enc.utf16 = sniffUTF16(content)
+	}
+	text := content[len(enc.bom):]
+
+	if enc.utf16 != nil {
+		units := make([]uint16, len(text)/2)
+		for i := range units {
+			units[i] = enc.utf16.Uint16(text[2*i:])
+		}
+		text = []byte(string(utf16.Decode(units)))
+	}
+
+	if i := bytes.IndexByte(text, '\n'); i > 0 && text[i-1] == '\r' {
+		enc.crlf = true
+	}
+	if bytes.Contains(text, crlf) {
+		stripped := make([]byte, 0, len(text))
+		for {
+			i := bytes.Index(text, crlf)
+			if i < 0 {
+				stripped = append(stripped, text...)
+				break
+			}
+			stripped = append(stripped, text[:i]...)
+			enc.crs = append(enc.crs, len(stripped))
+			stripped = append(stripped, '\n')
+			text = text[i+len(crlf):]
+		}
+		enc.text, text = stripped, bytes.Clone(stripped)
+	}
+	return text, enc
+}
+
+// encode encodes the UTF-8 text with LF line endings back in the encoding.
+func (enc encoding) encode(text []byte) []byte {
+	if len(enc.crs) > 0 {
+		text = enc.restoreLineEndings(text)
+	}
+	if enc.utf16 != nil {
+		units := utf16.Encode([]rune(string(text)))
+		encoded := make([]byte, 2*len(units))
+		for i, unit := range units {
+			enc.utf16.PutUint16(encoded[2*i:], unit)
+		}
+		text = encoded
+	}
+	return append(append([]byte(nil), enc.bom...), text...)
+}
+
+// restoreLineEndings restores the CRLF line endings of the text, the line breaks of the content that is unchanged
+// from the decoded text, i.e. the common prefix and suffix, are kept as they are in the file, and the ones of the
+// changed content, e.g. the inserted license header, are CRLF if the first line of the file is.
+func (enc encoding) restoreLineEndings(text []byte) []byte {
+	prefix := 0
+	for prefix < min(len(text), len(enc.text)) && text[prefix] == enc.text[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < min(len(text), len(enc.text))-prefix && text[len(text)-1-suffix] == enc.text[len(enc.text)-1-suffix] {
+		suffix++
+	}
+
+	restored := enc.restore(make([]byte, 0, len(text)+len(enc.crs)), 0, prefix)
+	changed := text[prefix : len(text)-suffix]
+	if enc.crlf {
+		changed = bytes.ReplaceAll(changed, []byte("\n"), crlf)
+	}
+	restored = append(restored, changed...)
+	return enc.restore(restored, len(enc.text)-suffix, len(enc.text))
+}
+
+// restore appends the decoded text from the start to the end to dst, with the line endings as they are in the file.
+func (enc encoding) restore(dst []byte, start, end int) []byte {
+	i, _ := slices.BinarySearch(enc.crs, start)
+	for ; i < len(enc.crs) && enc.crs[i] < end; i++ {
+		dst = append(append(dst, enc.text[start:enc.crs[i]]...), '\r')
+		start = enc.crs[i]
+	}
+	return append(dst, enc.text[start:end]...)
+}
+
+// sniffUTF16 tells whether the content is in UTF-16 without a BOM, by the NUL bytes in the head of it, which are
+// the high bytes of the ASCII characters, and returns the byte order, or nil if it's not UTF-16.
+func sniffUTF16(content []byte) binary.ByteOrder {
+	head := content[:min(len(content), sniffSize)]
+	if len(content)%2 != 0 || len(head) < 4 {
+		return nil
+	}
+	var even, odd int
+	for i, b := range head {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			even++
+		} else {
+			odd++
+		}
+	}
+	switch units := len(head) / 2; {
+	case even == 0 && odd*2 > units:
+		return binary.LittleEndian
+	case odd == 0 && even*2 > units:
+		return binary.BigEndian
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeUTF16(text string, order binary.AppendByteOrder) []byte {
+	var bs []byte
+	for _, unit := range utf16.Encode([]rune(text)) {
+		bs = order.AppendUint16(bs, unit)
+	}
+	return bs
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "LF", content: []byte("package main\n\nfunc main() {}\n")},
+		{name: "CRLF", content: []byte("package main\r\n\r\nfunc main() {}\r\n")},
+		{name: "mixed", content: []byte("package main\r\n\nfunc main() {}\n")},
+		{name: "UTF-8 BOM", content: []byte("\xEF\xBB\xBFpackage main\r\n")},
+		{name: "UTF-16LE BOM", content: append([]byte{0xFF, 0xFE}, encodeUTF16("package main\r\n// 你好\r\n", binary.LittleEndian)...)},
+		{name: "UTF-16BE BOM", content: append([]byte{0xFE, 0xFF}, encodeUTF16("package main\n", binary.BigEndian)...)},
+		{name: "UTF-16LE", content: encodeUTF16("package main\n", binary.LittleEndian)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, enc := decode(test.content)
+			require.NotContains(t, string(text), "\r")
+			require.True(t, bytes.HasPrefix(text, []byte("package main\n")), "%q", text)
+			require.Equal(t, test.content, enc.encode(text))
+		})
+	}
+}
+
+func TestEncodeKeepsLineEndings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		change   func(string) string
+		expected string
+	}{
+		{
+			name:     "update years",
+			content:  "// Copyright 2020 Foo\n// Licensed under MIT.\r\n\r\npackage main\n",
+			change:   func(text string) string { return strings.Replace(text, "2020", "2020-2026", 1) },
+			expected: "// Copyright 2020-2026 Foo\n// Licensed under MIT.\r\n\r\npackage main\n",
+		},
+		{
+			name:     "remove header",
+			content:  "// Copyright 2020 Foo\r\n\npackage main\r\n\nfunc main() {}\n",
+			change:   func(text string) string { return strings.TrimPrefix(text, "// Copyright 2020 Foo\n\n") },
+			expected: "package main\r\n\nfunc main() {}\n",
+		},
+		{
+			name:     "insert lines",
+			content:  "#!/bin/sh\r\necho 1\n",
+			change:   func(text string) string { return strings.Replace(text, "\n", "\n\n# Copyright Foo\n\n", 1) },
+			expected: "#!/bin/sh\r\n\r\n# Copyright Foo\r\n\r\necho 1\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, enc := decode([]byte(test.content))
+			require.Equal(t, test.expected, string(enc.encode([]byte(test.change(string(text))))))
+		})
+	}
+}
+
+func TestFixKeepsEncoding(t *testing.T) {
+	config := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"}}
+	require.NoError(t, config.Finalize())
+	header := []byte(getLicenseHeaderCustomConfig("main.go", t.Error, config))
+
+	tests := []struct {
+		name     string
+		content  []byte
+		expected []byte
+	}{
+		{
+			name:     "crlf.go",
+			content:  []byte("package main\r\n"),
+			expected: append(bytes.ReplaceAll(header, []byte("\n"), []byte("\r\n")), "package main\r\n"...),
+		},
+		{
+			name:     "mixed.go",
+			content:  []byte("package main\r\n\nfunc main() {}\n"),
+			expected: append(bytes.ReplaceAll(header, []byte("\n"), []byte("\r\n")), "package main\r\n\nfunc main() {}\n"...),
+		},
+		{
+			name:     "mixed-lf.go",
+			content:  []byte("package main\n\r\nfunc main() {}\r\n"),
+			expected: append(header, "package main\n\r\nfunc main() {}\r\n"...),
+		},
+		{
+			name:     "bom.go",
+			content:  []byte("\xEF\xBB\xBFpackage main\n"),
+			expected: append(append([]byte("\xEF\xBB\xBF"), header...), "package main\n"...),
+		},
+		{
+			name:     "utf16.go",
+			content:  append([]byte{0xFF, 0xFE}, encodeUTF16("package main\r\n", binary.LittleEndian)...),
+			expected: append([]byte{0xFF, 0xFE}, encodeUTF16(string(bytes.ReplaceAll(header, []byte("\n"), []byte("\r\n")))+"package main\r\n", binary.LittleEndian)...),
+		},
+	}
+	dir := t.TempDir()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(dir, test.name)
+			require.NoError(t, os.WriteFile(file, test.content, 0o600))
+
+			var result Result
+			require.NoError(t, CheckFile(file, config, &result))
+			require.Equal(t, []string{file}, result.Failure)
+			require.NoError(t, Fix(file, config, &result))
+
+			fixed, err := os.ReadFile(file)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, fixed)
+
+			result = Result{}
+			require.NoError(t, CheckFile(file, config, &result))
+			require.Equal(t, []string{file}, result.Success, "the fixed file should pass the check")
+		})
+	}
+}
@@ -36,9 +36,32 @@ func Fix(file string, config *ConfigHeader, result *Result) error {
 		return err
 	}
 
+	if r.Reason(file) == StaleYear {
+		return UpdateYears(file, config, result)
+	}
+
+	if isNotebook(file) {
+		return InsertNotebookCell(file, config, result)
+	}
+
 	style := comments.FileCommentStyle(file)
 
+	required, err := config.requiresLicensingInfo(file)
+	if err != nil {
+		return err
+	}
+	if required {
+		sidecar, err := needsSidecar(file, style)
+		if err != nil {
+			return err
+		}
+		if sidecar {
+			return CreateSidecar(file, config, result)
+		}
+	}
+
 	if style == nil {
+		result.Skip(file, UnsupportedStyle)
 		return fmt.Errorf("unsupported file: %v", file)
 	}
 
@@ -51,19 +74,25 @@ func InsertComment(file string, style *comments.CommentStyle, config *ConfigHead
 		return err
 	}
 
-	content, err := os.ReadFile(file)
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	content, enc := decode(raw)
+
+	licenseHeader, err := GenerateLicenseHeader(style, config, config.FileContext(file))
 	if err != nil {
 		return err
 	}
 
-	licenseHeader, err := GenerateLicenseHeader(style, config)
+	preambles, err := config.preambles(file)
 	if err != nil {
 		return err
 	}
 
-	content = rewriteContent(style, content, licenseHeader, config.LicensePattern(style))
+	fixed := rewriteContent(style, content, licenseHeader, config.LicensePattern(style), preambles)
 
-	if err := os.WriteFile(file, content, stat.Mode()); err != nil { //nolint:gosec // path from tool's own file scanner
+	if err := writeFixed(file, stat.Mode(), raw, enc.encode(fixed), config); err != nil {
 		return err
 	}
 
@@ -72,12 +101,26 @@ func InsertComment(file string, style *comments.CommentStyle, config *ConfigHead
 	return nil
 }
 
-func rewriteContent(style *comments.CommentStyle, content []byte, licenseHeader string, licensePattern *regexp.Regexp) []byte {
+// writeFixed writes the fixed content to the file, or adds the change to the patch in the dry-run mode.
+func writeFixed(file string, mode os.FileMode, original, fixed []byte, config *ConfigHeader) error {
+	if config.DryRun != nil {
+		config.DryRun.Add(file, mode, original, fixed)
+		return nil
+	}
+	return os.WriteFile(file, fixed, mode) //nolint:gosec // path from tool's own file scanner
+}
+
+func rewriteContent(style *comments.CommentStyle, content []byte, licenseHeader string, licensePattern *regexp.Regexp,
+	preambles []*regexp.Regexp) []byte {
 	// Remove previous license header version to allow update it
 	if licensePattern != nil {
 		content = licensePattern.ReplaceAll(content, []byte(""))
 	}
 
+	if end, ok := preambleEnd(style, content, preambles); ok {
+		return insertAfterPreamble(content, end, licenseHeader)
+	}
+
 	if style.After == "" {
 		return append([]byte(licenseHeader), content...)
 	}
@@ -100,12 +143,13 @@ func rewriteContent(style *comments.CommentStyle, content []byte, licenseHeader
 	)
 }
 
-func GenerateLicenseHeader(style *comments.CommentStyle, config *ConfigHeader) (string, error) {
+// GenerateLicenseHeader generates the license header of the file described by ctx, in the given comment style.
+func GenerateLicenseHeader(style *comments.CommentStyle, config *ConfigHeader, ctx *FileContext) (string, error) {
 	if err := style.Validate(); err != nil {
 		return "", err
 	}
 
-	content := config.GetLicenseContent()
+	content := config.LicenseContent(ctx)
 	// Trim leading and trailing newlines
 	content = strings.TrimSpace(content)
 	lines := strings.Split(content, "\n")
@@ -88,7 +88,7 @@ func TestFix(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.filename, func(t *testing.T) {
 			style := comments.FileCommentStyle(test.filename)
-			h, err := GenerateLicenseHeader(style, config)
+			h, err := GenerateLicenseHeader(style, config, config.FileContext(test.filename))
 			require.NoError(t, err, fmt.Sprintf("style: %+v", style))
 			require.Equal(t, test.comments, h, fmt.Sprintf("style: %+v", style))
 		})
@@ -617,14 +617,14 @@ html
 			if test.licensePattern != "" {
 				r = regexp.MustCompile(test.licensePattern)
 			}
-			content := rewriteContent(test.style, []byte(test.content), test.licenseHeader, r)
+			content := rewriteContent(test.style, []byte(test.content), test.licenseHeader, r, nil)
 			require.Equal(t, test.expectedContent, string(content), fmt.Sprintf("style: %+v", test.style))
 		})
 	}
 }
 
 func getLicenseHeader(filename string, tError func(args ...interface{})) string {
-	s, err := GenerateLicenseHeader(comments.FileCommentStyle(filename), config)
+	s, err := GenerateLicenseHeader(comments.FileCommentStyle(filename), config, config.FileContext(filename))
 	if err != nil {
 		tError(err)
 	}
@@ -632,7 +632,7 @@ func getLicenseHeader(filename string, tError func(args ...interface{})) string
 }
 
 func getLicenseHeaderCustomConfig(filename string, tError func(args ...interface{}), c *ConfigHeader) string {
-	s, err := GenerateLicenseHeader(comments.FileCommentStyle(filename), c)
+	s, err := GenerateLicenseHeader(comments.FileCommentStyle(filename), c, c.FileContext(filename))
 	if err != nil {
 		tError(err)
 	}
@@ -0,0 +1,93 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+)
+
+// generatedHeadSize is the size of the head of a file where the generated markers are looked for.
+const generatedHeadSize = 4096
+
+// linguistGenerated is the attribute in .gitattributes that marks the files as generated, see
+// https://github.com/github-linguist/linguist/blob/main/docs/overrides.md#generated-code.
+const linguistGenerated = "linguist-generated"
+
+// generatedMarkers are the well-known markers of the generated files.
+var generatedMarkers = []*regexp.Regexp{
+	// https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+	regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`),
+	// https://generated.at, at the start of a comment line
+	regexp.MustCompile(`(?m)^\W*@generated\b`),
+}
+
+// loadGitAttributes loads the patterns of the .gitattributes files in the directory and its subdirectories.
+func loadGitAttributes(dir string) ([]gitattributes.MatchAttribute, error) {
+	attributes, err := gitattributes.ReadPatterns(osfs.New(dir), nil)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return attributes, err
+}
+
+// isGenerated tells whether the file is generated, that is, it's marked by the linguist-generated attribute in
+// the .gitattributes files, or it has one of the generated markers at its head.
+func (config *ConfigHeader) isGenerated(file string) (bool, error) {
+	path := strings.Split(filepath.ToSlash(filepath.Clean(file)), "/")
+	generated, specified := false, false
+	for _, attribute := range config.gitAttributes {
+		if attribute.Pattern == nil || !attribute.Pattern.Match(path) {
+			continue
+		}
+		// the later patterns take precedence over the earlier ones, as git does
+		for _, attr := range attribute.Attributes {
+			if attr.Name() == linguistGenerated {
+				generated = attr.IsSet() || attr.IsValueSet() && attr.Value() == "true"
+				specified = true
+			}
+		}
+	}
+	if specified {
+		return generated, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	head := make([]byte, generatedHeadSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+	text, _ := decode(head[:n])
+	for _, marker := range generatedMarkers {
+		if marker.Match(text) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
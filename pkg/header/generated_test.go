@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFileSkipGenerated(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	files := map[string]string{
+		".gitattributes":         "gen/** linguist-generated\ngen/keep.go -linguist-generated\n",
+		"api/api.pb.go":          "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n",
+		"mocks/mock.py":          "# @generated by mockgen\n",
+		"docs/about.md":          "Files marked with @generated are skipped.\n",
+		"gen/model.go":           "package gen\n",
+		"gen/keep.go":            "package gen\n",
+		"main.go":                "package main\n",
+		"web/sub/.gitattributes": "*.js linguist-generated=true\n",
+		"web/sub/bundle.js":      "var a = 1;\n",
+		"web/sub/src/app.ts":     "let a = 1;\n",
+		"web/other/bundle.js":    "var a = 1;\n",
+		"web/sub/src/legacy.js":  "var a = 1;\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	config := &ConfigHeader{
+		License:       LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo"},
+		SkipGenerated: true,
+	}
+	require.NoError(t, config.Finalize())
+
+	var result Result
+	for _, file := range []string{
+		"api/api.pb.go", "mocks/mock.py", "docs/about.md", "gen/model.go", "gen/keep.go", "main.go",
+		"web/sub/bundle.js", "web/sub/src/legacy.js", "web/sub/src/app.ts", "web/other/bundle.js",
+	} {
+		require.NoError(t, CheckFile(file, config, &result))
+	}
+	require.Equal(t, []string{"api/api.pb.go", "mocks/mock.py", "gen/model.go", "web/sub/bundle.js", "web/sub/src/legacy.js"}, result.Ignored)
+	for _, file := range result.Ignored {
+		require.Equal(t, Generated, result.IgnoreReason(file))
+	}
+	require.Equal(t, []string{"docs/about.md", "gen/keep.go", "main.go", "web/sub/src/app.ts", "web/other/bundle.js"}, result.Failure)
+
+	config.SkipGenerated = false
+	result = Result{}
+	require.NoError(t, CheckFile("api/api.pb.go", config, &result))
+	require.Equal(t, []string{"api/api.pb.go"}, result.Failure, "the generated files are checked unless skip-generated is enabled")
+}
@@ -0,0 +1,281 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+// changedFiles returns the files changed between the merge base of the given revision and HEAD,
+// and HEAD itself, the changes in the worktree are not included.
+func changedFiles(repo *git.Repository, since string) ([]string, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve git revision %q: %w", since, err)
+	}
+	base, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	head, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := base.MergeBase(head)
+	if err != nil {
+		return nil, err
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("no merge base is found between %q and HEAD", since)
+	}
+
+	baseTree, err := bases[0].Tree()
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(baseTree, headTree)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		// Deleted files have no name in the "to" side and don't need to be checked.
+		if name := change.To.Name; name != "" {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// gitHistory is the years of the commits that change the files, which are resolved in one walk of the git history
+// on the first use, so that the history isn't walked for each of the files.
+type gitHistory struct {
+	once sync.Once
+	err  error
+	// first and last are the years of the first and the last commits that change the files, keyed by the paths.
+	first map[string]int
+	last  map[string]int
+	// blobs are the hashes of the files in HEAD, keyed by the paths.
+	blobs map[string]plumbing.Hash
+}
+
+func (history *gitHistory) load() error {
+	history.once.Do(func() {
+		history.err = history.walk()
+	})
+	return history.err
+}
+
+func (history *gitHistory) walk() error {
+	history.first, history.last, history.blobs = make(map[string]int), make(map[string]int), make(map[string]plumbing.Hash)
+
+	repo, err := git.PlainOpen(currentDir)
+	if err != nil {
+		return err
+	}
+	ref, err := repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) { // no commit yet
+		return nil
+	} else if err != nil {
+		return err
+	}
+	head, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return err
+	}
+	tree, err := head.Tree()
+	if err != nil {
+		return err
+	}
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		if entry.Mode.IsFile() {
+			history.blobs[name] = entry.Hash
+		}
+	}
+
+	shallow, err := repo.Storer.Shallow()
+	if err != nil {
+		return err
+	}
+	complete := true
+	defer func() {
+		if !complete {
+			logger.Log.Warnln("The git history is incomplete, e.g. a shallow clone, the copyright years from git may be later than the actual ones," +
+				" fetch the full history (e.g. fetch-depth: 0 of actions/checkout) for the accurate ones")
+		}
+	}()
+
+	// the commits are walked in any order, as the years are compared anyway
+	seen := map[plumbing.Hash]bool{ref.Hash(): true}
+	for queue := []plumbing.Hash{ref.Hash()}; len(queue) > 0; queue = queue[1:] {
+		commit, err := repo.CommitObject(queue[0])
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			complete = false
+			continue
+		} else if err != nil {
+			return err
+		}
+		boundary := slices.Contains(shallow, commit.Hash)
+		paths, full, err := changedPaths(commit, boundary)
+		if err != nil {
+			return err
+		}
+		complete = complete && full
+
+		year := commit.Author.When.Year()
+		for _, path := range paths {
+			if first, ok := history.first[path]; !ok || year < first {
+				history.first[path] = year
+			}
+			history.last[path] = max(history.last[path], year)
+		}
+		if boundary {
+			continue
+		}
+		for _, parent := range commit.ParentHashes {
+			if !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return nil
+}
+
+// changedPaths returns the paths of the files that the commit adds or modifies, a merge commit only changes
+// the files that differ from all its parents, e.g. the ones modified when resolving the conflicts.
+// The commit at the boundary of a shallow clone, or whose parents are missing, is taken as a root commit that adds
+// all its files, complete is false then.
+func changedPaths(commit *object.Commit, shallow bool) (paths []string, complete bool, err error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, false, err
+	}
+	if commit.NumParents() == 0 || shallow {
+		paths, err = diffPaths(nil, tree)
+		return paths, !shallow, err
+	}
+
+	counts := make(map[string]int)
+	if err := commit.Parents().ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return err
+		}
+		paths, err := diffPaths(parentTree, tree)
+		for _, path := range paths {
+			counts[path]++
+		}
+		return err
+	}); errors.Is(err, plumbing.ErrObjectNotFound) {
+		paths, err = diffPaths(nil, tree)
+		return paths, false, err
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	for path, count := range counts {
+		if count == commit.NumParents() {
+			paths = append(paths, path)
+		}
+	}
+	return paths, true, nil
+}
+
+// diffPaths returns the paths of the files that are added or modified from the tree to the other one.
+func diffPaths(from, to *object.Tree) ([]string, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		// Deleted files have no name in the "to" side.
+		if name := change.To.Name; name != "" {
+			paths = append(paths, name)
+		}
+	}
+	return paths, nil
+}
+
+// lastModifiedYear returns the year of the last commit that changes the file,
+// or the current year if the file is changed in the worktree or not committed yet.
+func (history *gitHistory) lastModifiedYear(file string) (int, error) {
+	if err := history.load(); err != nil {
+		return 0, err
+	}
+
+	path := filepath.ToSlash(filepath.Clean(file))
+	committed, ok := history.blobs[path]
+	if !ok {
+		return time.Now().Year(), nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+	if plumbing.ComputeHash(plumbing.BlobObject, content) != committed {
+		return time.Now().Year(), nil
+	}
+	return history.last[path], nil
+}
+
+// firstCommitYear returns the year of the first commit that adds the file,
+// or the current year if the file is not committed yet.
+func (history *gitHistory) firstCommitYear(file string) (int, error) {
+	if err := history.load(); err != nil {
+		return 0, err
+	}
+
+	if year, ok := history.first[filepath.ToSlash(filepath.Clean(file))]; ok {
+		return year, nil
+	}
+	return time.Now().Year(), nil
+}
@@ -0,0 +1,193 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	"github.com/apache/skywalking-eyes/pkg/license"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+// identifyThreshold is the minimum coverage percentage for a comment block to be identified as a license header.
+const identifyThreshold = 75
+
+// Migrate replaces the existing license header of any known license in the file with the configured one,
+// the existing license header is the first comment block of the file, within the license-location-threshold,
+// that is identified as a known license.
+// The identified license of the replaced header is returned, or an empty string if the file has no such header,
+// in which case the configured license header is inserted as Fix does. The first cell of the Jupyter notebooks
+// is replaced with the cell of the configured license header, or the cell is inserted.
+func Migrate(file string, config *ConfigHeader, result *Result) (string, error) {
+	var r Result
+	if err := CheckFile(file, config, &r); err != nil || !r.HasFailure() {
+		logger.Log.Warnln("Try to migrate a valid file, do nothing:", file)
+		return "", err
+	}
+
+	if r.Reason(file) == StaleYear {
+		return "", UpdateYears(file, config, result)
+	}
+	if isNotebook(file) {
+		return migrateNotebookCell(file, config, result)
+	}
+
+	style := comments.FileCommentStyle(file)
+	if style == nil {
+		return "", fmt.Errorf("unsupported file: %v", file)
+	}
+
+	stat, err := os.Stat(file)
+	if err != nil {
+		return "", err
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	content, enc := decode(raw)
+
+	offset, base, err := config.headerStart(file, style, string(content))
+	if err != nil {
+		return "", err
+	}
+	var spdxID string
+	start, end := findComment(style, string(content), offset, base, config.LicenseLocationThreshold, func(block string) bool {
+		id, err := license.Identify(license.CommentIndicatorNormalizer(block), identifyThreshold)
+		if err != nil {
+			logger.Log.Debugln("The comment block is not a license header:", file, err)
+			return false
+		}
+		spdxID = id
+		return true
+	})
+	if start < 0 {
+		return "", InsertComment(file, style, config, result)
+	}
+
+	licenseHeader, err := GenerateLicenseHeader(style, config, config.FileContext(file))
+	if err != nil {
+		return "", err
+	}
+	for end < len(content) { // the blank lines after the replaced header are replaced too
+		line, next := lineAt(string(content), end)
+		if strings.TrimSpace(line) != "" {
+			break
+		}
+		end = next
+	}
+	fixed := append(append(content[:start:start], licenseHeader...), content[end:]...)
+
+	if err := writeFixed(file, stat.Mode(), raw, enc.encode(fixed), config); err != nil {
+		return "", err
+	}
+
+	result.Fix(file)
+
+	return spdxID, nil
+}
+
+// headerStart returns the offset that the license header can start from in the content, that is after the preamble
+// if any preamble rule of the file matches, otherwise after the content that the license header must be placed after,
+// e.g. shebang. It returns the offset that the license-location-threshold is measured from as well, as CheckFile does.
+func (config *ConfigHeader) headerStart(file string, style *comments.CommentStyle, content string) (offset, base int, err error) {
+	preambles, err := config.preambles(file)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end, ok := preambleEnd(style, []byte(content), preambles); ok {
+		return end, end, nil
+	}
+	return skipPreamble(style, content), 0, nil
+}
+
+// findComment walks the comment blocks from the offset, and locates the first one that is found by the function,
+// within the license-location-threshold measured from the base, or returns -1, -1 if there isn't one.
+func findComment(style *comments.CommentStyle, content string, offset, base, threshold int, found func(block string) bool) (start, end int) {
+	for end = offset; ; {
+		if start, end = commentAt(style, content, end); start < 0 {
+			return -1, -1
+		}
+		if len(license.NormalizeHeader(content[base:start])) >= threshold {
+			return -1, -1
+		}
+		if found(content[start:end]) {
+			return start, end
+		}
+	}
+}
+
+// skipPreamble returns the offset of the line after the content that the license header must be placed after,
+// e.g. shebang, or 0 if there is no such content.
+func skipPreamble(style *comments.CommentStyle, content string) (offset int) {
+	if style.After != "" {
+		if loc := regexp.MustCompile(style.After).FindStringIndex(content); loc != nil && strings.TrimSpace(content[:loc[0]]) == "" {
+			_, offset = lineAt(content, loc[1])
+		}
+	}
+	return offset
+}
+
+// commentAt locates the comment block that starts at the first non-blank line from the offset,
+// it returns -1, -1 if that line doesn't start a comment block.
+func commentAt(style *comments.CommentStyle, content string, offset int) (start, end int) {
+	for start = offset; start < len(content); {
+		line, next := lineAt(content, start)
+		if strings.TrimSpace(line) != "" {
+			break
+		}
+		start = next
+	}
+
+	startMark, endMark := strings.TrimSpace(style.Start), strings.TrimSpace(style.End)
+	line, next := lineAt(content, start)
+	if startMark == "" || !strings.HasPrefix(strings.TrimSpace(line), startMark) {
+		return -1, -1
+	}
+
+	if style.Start != style.Middle { // block comment, ends with the end mark
+		from := start + strings.Index(line, startMark) + len(startMark)
+		index := strings.Index(content[from:], endMark)
+		if endMark == "" || index < 0 {
+			return -1, -1
+		}
+		_, end = lineAt(content, from+index)
+		return start, end
+	}
+
+	// line comments, end with the first line that isn't a comment
+	for end = next; end < len(content); end = next {
+		if line, next = lineAt(content, end); !strings.HasPrefix(strings.TrimSpace(line), startMark) {
+			break
+		}
+	}
+	return start, end
+}
+
+// lineAt returns the line that contains the offset, without the line break, and the offset of the next line.
+func lineAt(content string, offset int) (line string, next int) {
+	begin := strings.LastIndex(content[:offset], "\n") + 1
+	if index := strings.IndexByte(content[offset:], '\n'); index >= 0 {
+		return content[begin : offset+index], offset + index + 1
+	}
+	return content[begin:], len(content)
+}
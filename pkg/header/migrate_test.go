@@ -0,0 +1,140 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+)
+
+func TestFindComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		block   string
+	}{
+		{
+			name:    "line comments",
+			file:    "a.go",
+			content: "// Copyright Foo\n//\n// Licensed under MIT.\n\npackage main\n",
+			block:   "// Copyright Foo\n//\n// Licensed under MIT.\n",
+		},
+		{
+			name:    "block comment",
+			file:    "A.java",
+			content: "\n/*\n * Copyright Foo\n */\npackage foo;\n",
+			block:   "/*\n * Copyright Foo\n */\n",
+		},
+		{
+			name:    "after shebang",
+			file:    "a.py",
+			content: "#!/usr/bin/env python\n\n# Copyright Foo\nprint(1)\n",
+			block:   "# Copyright Foo\n",
+		},
+		{
+			name:    "after other comments",
+			file:    "a.go",
+			content: "//go:build linux\n\n// Copyright Foo\n\npackage main\n",
+			block:   "// Copyright Foo\n",
+		},
+		{
+			name:    "no comment",
+			file:    "b.go",
+			content: "package main\n\n// Copyright Foo\n",
+		},
+		{
+			name:    "beyond the threshold",
+			file:    "c.go",
+			content: "// " + strings.Repeat("build tags ", 10) + "\n\n// Copyright Foo\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			style := comments.FileCommentStyle(test.file)
+			start, end := findComment(style, test.content, skipPreamble(style, test.content), 0, 80, func(block string) bool {
+				return strings.Contains(block, "Copyright")
+			})
+			if test.block == "" {
+				require.Equal(t, -1, start)
+				return
+			}
+			require.Equal(t, test.block, test.content[start:end])
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	dir := t.TempDir()
+	gpl := filepath.Join(dir, "gpl.go")
+	gplContent := []byte(`// Copyright (C) 2015 Foo
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+`)
+	require.NoError(t, os.WriteFile(gpl, gplContent, 0o600))
+	constrained := filepath.Join(dir, "constrained.go")
+	require.NoError(t, os.WriteFile(constrained, append([]byte("//go:build linux\n\n"), gplContent...), 0o600))
+	doc := filepath.Join(dir, "doc.go")
+	require.NoError(t, os.WriteFile(doc, []byte("// Package main does nothing.\npackage main\n"), 0o600))
+
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"}}
+	require.NoError(t, c.Finalize())
+
+	var result Result
+	replaced, err := Migrate(gpl, c, &result)
+	require.NoError(t, err)
+	require.Equal(t, "GPL-3.0-or-later", replaced)
+	content, err := os.ReadFile(gpl)
+	require.NoError(t, err)
+	require.Equal(t, getLicenseHeaderCustomConfig("gpl.go", t.Error, c)+"package main\n", string(content))
+
+	replaced, err = Migrate(constrained, c, &result)
+	require.NoError(t, err)
+	require.Equal(t, "GPL-3.0-or-later", replaced)
+	content, err = os.ReadFile(constrained)
+	require.NoError(t, err)
+	require.Equal(t, "//go:build linux\n\n"+getLicenseHeaderCustomConfig("constrained.go", t.Error, c)+"package main\n", string(content))
+
+	replaced, err = Migrate(doc, c, &result)
+	require.NoError(t, err)
+	require.Empty(t, replaced)
+	content, err = os.ReadFile(doc)
+	require.NoError(t, err)
+	require.Equal(t, getLicenseHeaderCustomConfig("doc.go", t.Error, c)+"// Package main does nothing.\npackage main\n", string(content))
+
+	require.Equal(t, []string{gpl, constrained, doc}, result.Fixed)
+}
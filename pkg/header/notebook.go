@@ -0,0 +1,368 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+// NotebookCell is the type of the cell that the license header is inserted in the Jupyter notebooks.
+type NotebookCell string
+
+const (
+	// MarkdownCell inserts the license header as the plain text in a markdown cell, which is the default.
+	MarkdownCell NotebookCell = "markdown"
+	// CodeCell inserts the license header as the comments in a code cell, in the comment style of the notebook language.
+	CodeCell NotebookCell = "code"
+)
+
+func (cell NotebookCell) validate() error {
+	switch cell {
+	case "", MarkdownCell, CodeCell:
+		return nil
+	}
+	return fmt.Errorf("unsupported notebook cell %q, supported cells are %v", cell, []NotebookCell{MarkdownCell, CodeCell})
+}
+
+// headerCellID is the id of the cell of the license header, the ids of the cells are required since nbformat 4.5,
+// and must be unique in the notebook, so a suffix is added if another cell has the id, e.g. "license-header-1".
+const headerCellID = "license-header"
+
+// notebook is the part of the Jupyter notebook that the license header is concerned with,
+// see https://nbformat.readthedocs.io/en/latest/format_description.html.
+type notebook struct {
+	Cells []struct {
+		ID     string          `json:"id"`
+		Source json.RawMessage `json:"source"`
+	} `json:"cells"`
+	Metadata struct {
+		Kernelspec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+	NbformatMinor int `json:"nbformat_minor"`
+}
+
+// language returns the programming language of the notebook.
+func (nb *notebook) language() string {
+	if nb.Metadata.LanguageInfo.Name != "" {
+		return nb.Metadata.LanguageInfo.Name
+	}
+	return nb.Metadata.Kernelspec.Language
+}
+
+// headerCellID returns the id of the cell of the license header, which is unique among the ids of the cells.
+func (nb *notebook) headerCellID() string {
+	ids := make(map[string]bool, len(nb.Cells))
+	for _, cell := range nb.Cells {
+		ids[cell.ID] = true
+	}
+	id := headerCellID
+	for i := 1; ids[id]; i++ {
+		id = fmt.Sprintf("%v-%d", headerCellID, i)
+	}
+	return id
+}
+
+// isNotebook tells whether the file is a Jupyter notebook.
+func isNotebook(file string) bool {
+	return strings.EqualFold(filepath.Ext(file), ".ipynb")
+}
+
+// firstCell returns the source of the first cell of the notebook, where the license header is.
+// It returns false if the content is not a valid notebook.
+func firstCell(content []byte) (string, bool) {
+	var nb notebook
+	if err := json.Unmarshal(content, &nb); err != nil {
+		return "", false
+	}
+	if len(nb.Cells) == 0 {
+		return "", true
+	}
+	// the source is either a string or a list of lines
+	var source string
+	if err := json.Unmarshal(nb.Cells[0].Source, &source); err == nil {
+		return source, true
+	}
+	var lines []string
+	if err := json.Unmarshal(nb.Cells[0].Source, &lines); err != nil {
+		return "", false
+	}
+	return strings.Join(lines, ""), true
+}
+
+// notebookFile is the Jupyter notebook read from a file, with what it takes to write the fixed one back as is.
+type notebookFile struct {
+	notebook
+	mode    os.FileMode
+	raw     []byte
+	content []byte
+	enc     encoding
+}
+
+func readNotebook(file string) (*notebookFile, error) {
+	stat, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	nb := &notebookFile{mode: stat.Mode(), raw: raw}
+	nb.content, nb.enc = decode(raw)
+	if err := json.Unmarshal(nb.content, &nb.notebook); err != nil {
+		return nil, fmt.Errorf("invalid notebook %v: %w", file, err)
+	}
+	return nb, nil
+}
+
+func (nb *notebookFile) write(file string, fixed []byte, config *ConfigHeader) error {
+	return writeFixed(file, nb.mode, nb.raw, nb.enc.encode(fixed), config)
+}
+
+// InsertNotebookCell inserts a new first cell with the license header into the notebook, the other cells and the
+// formatting of the notebook are kept as is.
+func InsertNotebookCell(file string, config *ConfigHeader, result *Result) error {
+	nb, err := readNotebook(file)
+	if err != nil {
+		return err
+	}
+
+	cell, err := config.headerCell(file, &nb.notebook)
+	if err != nil {
+		return err
+	}
+	fixed, err := insertCell(nb.content, cell)
+	if err != nil {
+		return fmt.Errorf("invalid notebook %v: %w", file, err)
+	}
+
+	if err := nb.write(file, fixed, config); err != nil {
+		return err
+	}
+
+	result.Fix(file)
+
+	return nil
+}
+
+// removeNotebookCell removes the first cell of the notebook if it has the configured license header.
+func removeNotebookCell(file string, config *ConfigHeader, result *Result) error {
+	nb, err := readNotebook(file)
+	if err != nil {
+		return err
+	}
+
+	source, _ := firstCell(nb.content)
+	matched := false
+	for _, alternative := range config.alternatives() {
+		if matched = matches(source, lcs.NormalizeHeader(source), alternative, alternative.FileContext(file)); matched {
+			break
+		}
+	}
+	if !matched {
+		logger.Log.Debugln("No license header cell to remove:", file)
+		return nil
+	}
+
+	removed, err := removeFirstCell(nb.content)
+	if err != nil {
+		return fmt.Errorf("invalid notebook %v: %w", file, err)
+	}
+	if err := nb.write(file, removed, config); err != nil {
+		return err
+	}
+
+	result.Remove(file)
+
+	return nil
+}
+
+// migrateNotebookCell replaces the first cell of the notebook with the cell of the configured license header if
+// it's identified as a known license, otherwise the cell is inserted, the identified license is returned.
+func migrateNotebookCell(file string, config *ConfigHeader, result *Result) (string, error) {
+	nb, err := readNotebook(file)
+	if err != nil {
+		return "", err
+	}
+
+	content, spdxID := nb.content, ""
+	if source, _ := firstCell(content); source != "" {
+		if id, err := lcs.Identify(lcs.CommentIndicatorNormalizer(source), identifyThreshold); err == nil {
+			if content, err = removeFirstCell(content); err != nil {
+				return "", fmt.Errorf("invalid notebook %v: %w", file, err)
+			}
+			// the id of the removed cell can be taken by the new one
+			nb.Cells, spdxID = nb.Cells[1:], id
+		} else {
+			logger.Log.Debugln("The first cell is not a license header:", file, err)
+		}
+	}
+
+	cell, err := config.headerCell(file, &nb.notebook)
+	if err != nil {
+		return "", err
+	}
+	fixed, err := insertCell(content, cell)
+	if err != nil {
+		return "", fmt.Errorf("invalid notebook %v: %w", file, err)
+	}
+	if err := nb.write(file, fixed, config); err != nil {
+		return "", err
+	}
+
+	result.Fix(file)
+
+	return spdxID, nil
+}
+
+// headerCell returns the cell of the license header for the notebook, in the type of the config.
+func (config *ConfigHeader) headerCell(file string, nb *notebook) (map[string]any, error) {
+	var source string
+	cell := map[string]any{"metadata": map[string]any{}}
+	if config.NotebookCell == CodeCell {
+		style := comments.LanguageCommentStyle(nb.language())
+		if style == nil {
+			return nil, fmt.Errorf("unsupported language %q of the notebook: %v", nb.language(), file)
+		}
+		licenseHeader, err := GenerateLicenseHeader(style, config, config.FileContext(file))
+		if err != nil {
+			return nil, err
+		}
+		source = strings.TrimRight(licenseHeader, "\n")
+		cell["cell_type"], cell["execution_count"], cell["outputs"] = "code", nil, []any{}
+	} else {
+		source = strings.TrimSpace(config.LicenseContent(config.FileContext(file)))
+		cell["cell_type"] = "markdown"
+	}
+	if nb.NbformatMinor >= 5 {
+		cell["id"] = nb.headerCellID()
+	}
+
+	// the source is stored as a list of lines, each ends with the line break except the last one, as Jupyter does
+	cell["source"] = strings.SplitAfter(source, "\n")
+	return cell, nil
+}
+
+// insertCell inserts the cell at the start of the cells of the notebook content, in the indentation of the content.
+func insertCell(content []byte, cell map[string]any) ([]byte, error) {
+	open, err := cellsOffset(content)
+	if err != nil {
+		return nil, err
+	}
+	ws := len(content[open:]) - len(bytes.TrimLeft(content[open:], " \t\n"))
+	empty := open+ws < len(content) && content[open+ws] == ']'
+
+	// the indentation of the "cells" key, which is in the top-level object, tells the indentation unit
+	lineStart := bytes.LastIndexByte(content[:open], '\n') + 1
+	line := content[lineStart:open]
+	keyIndent := string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+	compact := lineStart == 0
+	elemIndent := keyIndent + keyIndent
+	if i := bytes.LastIndexByte(content[open:open+ws], '\n'); !empty && i >= 0 {
+		elemIndent = string(content[open+i+1 : open+ws])
+	}
+	unit := strings.TrimPrefix(elemIndent, keyIndent)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if !compact {
+		encoder.SetIndent(elemIndent, unit)
+	}
+	if err := encoder.Encode(cell); err != nil {
+		return nil, err
+	}
+	encoded := bytes.TrimRight(buf.Bytes(), "\n")
+
+	fixed := append([]byte(nil), content[:open]...)
+	switch {
+	case compact && empty:
+		fixed = append(fixed, encoded...)
+	case compact:
+		fixed = append(append(fixed, encoded...), ',')
+	case empty:
+		fixed = append(append(append(fixed, "\n"+elemIndent...), encoded...), "\n"+keyIndent...)
+		return append(fixed, content[open+ws:]...), nil
+	default:
+		fixed = append(append(append(fixed, "\n"+elemIndent...), encoded...), ',')
+	}
+	return append(fixed, content[open:]...), nil
+}
+
+// removeFirstCell removes the first cell of the notebook content, along with the separator after it,
+// so that the next cell takes its place in the same indentation.
+func removeFirstCell(content []byte) ([]byte, error) {
+	open, err := cellsOffset(content)
+	if err != nil {
+		return nil, err
+	}
+	start := open + len(content[open:]) - len(bytes.TrimLeft(content[open:], " \t\r\n"))
+	decoder := json.NewDecoder(bytes.NewReader(content[start:]))
+	var cell json.RawMessage
+	if err := decoder.Decode(&cell); err != nil {
+		return nil, err
+	}
+	end := start + int(decoder.InputOffset())
+	rest := bytes.TrimLeft(content[end:], " \t\r\n")
+	if next, ok := bytes.CutPrefix(rest, []byte(",")); ok { // the next cell takes the place of the first one
+		rest = bytes.TrimLeft(next, " \t\r\n")
+		return append(content[:start:start], rest...), nil
+	}
+	// it's the only cell
+	return append(content[:open:open], rest...), nil
+}
+
+// cellsOffset returns the offset right after the "[" of the cells in the notebook content.
+func cellsOffset(content []byte) (int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return 0, errors.New("the notebook is not a JSON object")
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return 0, err
+		}
+		if key == "cells" {
+			if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+				return 0, errors.New("the cells of the notebook is not a list")
+			}
+			return int(decoder.InputOffset()), nil
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return 0, err
+		}
+	}
+	return 0, errors.New("the notebook has no cells")
+}
@@ -0,0 +1,171 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/assets"
+)
+
+const testNotebook = `{
+ "cells": [
+  {
+   "cell_type": "code",
+   "execution_count": 1,
+   "id": "a1b2c3",
+   "metadata": {},
+   "outputs": [],
+   "source": [
+    "print('<hello>')"
+   ]
+  }
+ ],
+ "metadata": {
+  "kernelspec": {
+   "display_name": "Python 3",
+   "language": "python",
+   "name": "python3"
+  }
+ },
+ "nbformat": 4,
+ "nbformat_minor": 5
+}
+`
+
+func TestFixNotebook(t *testing.T) {
+	config := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"}}
+	require.NoError(t, config.Finalize())
+
+	tests := []struct {
+		name     string
+		cell     NotebookCell
+		content  string
+		cellType string
+		prefix   string
+		id       string
+	}{
+		{name: "markdown.ipynb", cell: MarkdownCell, content: testNotebook, cellType: "markdown", prefix: "Copyright 2026 Foo\n", id: headerCellID},
+		{name: "code.ipynb", cell: CodeCell, content: testNotebook, cellType: "code", prefix: "# Copyright 2026 Foo\n", id: headerCellID},
+		{
+			name:     "id.ipynb",
+			cell:     MarkdownCell,
+			content:  strings.Replace(testNotebook, `"a1b2c3"`, `"license-header"`, 1),
+			cellType: "markdown",
+			prefix:   "Copyright 2026 Foo\n",
+			id:       "license-header-1",
+		},
+		{name: "empty.ipynb", cell: MarkdownCell, content: `{"cells": [], "nbformat": 4, "nbformat_minor": 4}`, cellType: "markdown"},
+		{
+			name:     "crlf.ipynb",
+			cell:     MarkdownCell,
+			content:  "{\r\n  \"cells\": [],\r\n  \"nbformat\": 4,\r\n  \"nbformat_minor\": 2\r\n}\r\n",
+			cellType: "markdown",
+		},
+	}
+	dir := t.TempDir()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := *config
+			config.NotebookCell = test.cell
+			file := filepath.Join(dir, test.name)
+			require.NoError(t, os.WriteFile(file, []byte(test.content), 0o600))
+
+			var result Result
+			require.NoError(t, CheckFile(file, &config, &result))
+			require.Equal(t, []string{file}, result.Failure)
+			require.NoError(t, Fix(file, &config, &result))
+
+			fixed, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var nb struct {
+				Cells []struct {
+					CellType string   `json:"cell_type"`
+					ID       string   `json:"id"`
+					Source   []string `json:"source"`
+				} `json:"cells"`
+			}
+			require.NoError(t, json.Unmarshal(fixed, &nb), "the fixed notebook should be valid JSON:\n%s", fixed)
+			require.Equal(t, test.cellType, nb.Cells[0].CellType)
+			require.True(t, strings.HasPrefix(strings.Join(nb.Cells[0].Source, ""), test.prefix))
+
+			// the original content is kept as is after the inserted cell
+			if strings.Contains(test.content, `"cells": [],`) {
+				require.Equal(t, strings.Contains(test.content, "\r\n"), strings.Contains(string(fixed), "\r\n"))
+			} else {
+				require.Equal(t, test.id, nb.Cells[0].ID, "the id of the header cell should be unique")
+				require.True(t, strings.HasPrefix(string(fixed), "{\n \"cells\": [\n  {\n   \"cell_type\": \""+test.cellType+"\",\n"))
+				require.Contains(t, string(fixed), "  },\n"+strings.TrimPrefix(test.content, "{\n \"cells\": [\n"))
+				require.NotContains(t, string(fixed), `\u003c`)
+			}
+
+			result = Result{}
+			require.NoError(t, CheckFile(file, &config, &result))
+			require.Equal(t, []string{file}, result.Success, "the fixed notebook should pass the check")
+		})
+	}
+}
+
+func TestRemoveAndMigrateNotebook(t *testing.T) {
+	config := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"}}
+	require.NoError(t, config.Finalize())
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"cells.ipynb":   testNotebook,
+		"empty.ipynb":   "{\n \"cells\": [],\n \"nbformat\": 4,\n \"nbformat_minor\": 5\n}\n",
+		"compact.ipynb": `{"cells": [], "nbformat": 4, "nbformat_minor": 4}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+			var result Result
+			require.NoError(t, Fix(file, config, &result))
+			fixed, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			require.NoError(t, Remove(file, config, &result))
+			removed, err := os.ReadFile(file)
+			require.NoError(t, err)
+			require.Equal(t, content, string(removed), "the notebook should be restored after removing the header cell")
+			require.Equal(t, []string{file}, result.Removed)
+
+			mit, err := assets.Asset("lcs-templates/MIT.txt")
+			require.NoError(t, err)
+			// the id of the replaced cell is reused by the header cell
+			mitCell := map[string]any{"cell_type": "markdown", "id": headerCellID, "metadata": map[string]any{}, "source": []string{string(mit)}}
+			withMIT, err := insertCell([]byte(content), mitCell)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(file, withMIT, 0o600))
+
+			replaced, err := Migrate(file, config, &result)
+			require.NoError(t, err)
+			require.Equal(t, "MIT", replaced)
+			migrated, err := os.ReadFile(file)
+			require.NoError(t, err)
+			require.Equal(t, string(fixed), string(migrated))
+		})
+	}
+}
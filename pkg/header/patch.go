@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	unified "github.com/go-git/go-git/v5/plumbing/format/diff"
+	linediff "github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Patch collects the changes that would be made to the files, instead of writing them to the files,
+// so that they can be reviewed before being applied. It's safe for concurrent use.
+type Patch struct {
+	mu    sync.Mutex
+	files []*filePatch
+}
+
+// Add adds the change of the file from the original content to the fixed content.
+func (patch *Patch) Add(file string, mode os.FileMode, from, to []byte) {
+	patch.add(file, mode, from, to, false)
+}
+
+// Create adds the creation of the file with the content.
+func (patch *Patch) Create(file string, mode os.FileMode, content []byte) {
+	patch.add(file, mode, nil, content, true)
+}
+
+func (patch *Patch) add(file string, mode os.FileMode, from, to []byte, created bool) {
+	m, err := filemode.NewFromOSFileMode(mode)
+	if err != nil {
+		m = filemode.Regular
+	}
+
+	patch.mu.Lock()
+	defer patch.mu.Unlock()
+	patch.files = append(patch.files, &filePatch{
+		path:    filepath.ToSlash(filepath.Clean(file)),
+		mode:    m,
+		from:    string(from),
+		to:      string(to),
+		created: created,
+	})
+}
+
+// Empty tells whether there is no change in the patch.
+func (patch *Patch) Empty() bool {
+	patch.mu.Lock()
+	defer patch.mu.Unlock()
+	return len(patch.files) == 0
+}
+
+// Encode writes the changes in the unified diff format, sorted by the file paths,
+// the output can be applied by `git apply`.
+func (patch *Patch) Encode(w io.Writer) error {
+	patch.mu.Lock()
+	files := append(unifiedPatch(nil), patch.files...)
+	patch.mu.Unlock()
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+	return unified.NewUnifiedEncoder(w, unified.DefaultContextLines).Encode(files)
+}
+
+// unifiedPatch adapts the file patches to the unified.Patch.
+type unifiedPatch []*filePatch
+
+func (unifiedPatch) Message() string {
+	return ""
+}
+
+func (p unifiedPatch) FilePatches() []unified.FilePatch {
+	patches := make([]unified.FilePatch, len(p))
+	for i, file := range p {
+		patches[i] = file
+	}
+	return patches
+}
+
+type filePatch struct {
+	path     string
+	mode     filemode.FileMode
+	from, to string
+	created  bool
+}
+
+func (*filePatch) IsBinary() bool {
+	return false
+}
+
+func (p *filePatch) Files() (from, to unified.File) {
+	if !p.created {
+		from = &patchFile{patch: p, content: p.from}
+	}
+	return from, &patchFile{patch: p, content: p.to}
+}
+
+func (p *filePatch) Chunks() []unified.Chunk {
+	var chunks []unified.Chunk
+	for _, d := range linediff.Do(p.from, p.to) {
+		op := unified.Equal
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = unified.Add
+		case diffmatchpatch.DiffDelete:
+			op = unified.Delete
+		}
+		chunks = append(chunks, &patchChunk{content: d.Text, op: op})
+	}
+	return chunks
+}
+
+type patchFile struct {
+	patch   *filePatch
+	content string
+}
+
+func (f *patchFile) Hash() plumbing.Hash {
+	return plumbing.ComputeHash(plumbing.BlobObject, []byte(f.content))
+}
+
+func (f *patchFile) Mode() filemode.FileMode {
+	return f.patch.mode
+}
+
+func (f *patchFile) Path() string {
+	return f.patch.path
+}
+
+type patchChunk struct {
+	content string
+	op      unified.Operation
+}
+
+func (c *patchChunk) Content() string {
+	return c.content
+}
+
+func (c *patchChunk) Type() unified.Operation {
+	return c.op
+}
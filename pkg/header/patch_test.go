@@ -0,0 +1,83 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchEncode(t *testing.T) {
+	var patch Patch
+	require.True(t, patch.Empty())
+
+	patch.Add("b.py", 0o644, []byte("print(1)\n"), []byte("# Copyright Foo\n\nprint(1)\n"))
+	patch.Add("a.go", 0o755, []byte("package main\n"), []byte("// Copyright Foo\n\npackage main\n"))
+	require.False(t, patch.Empty())
+
+	var out strings.Builder
+	require.NoError(t, patch.Encode(&out))
+	require.Equal(t, `diff --git a/a.go b/a.go
+index 06ab7d0f9a35a7d1070711496d6ca1cb892a258f..164bc358e73ec61d6c5f50094eba0193167300ca 100755
+--- a/a.go
++++ b/a.go
+@@ -1 +1,3 @@
++// Copyright Foo
++
+ package main
+diff --git a/b.py b/b.py
+index b917a726c93f902e43291d9009d6488385133b67..2b9e8d070ceb51e246469ff8d978ecaaa49622b8 100644
+--- a/b.py
++++ b/b.py
+@@ -1 +1,3 @@
++# Copyright Foo
++
+ print(1)
+`, out.String())
+}
+
+func TestFixDryRun(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(file, []byte("package main\n"), 0o600))
+
+	c := &ConfigHeader{License: LicenseConfig{Content: "Copyright Foo"}, DryRun: new(Patch)}
+	require.NoError(t, c.Finalize())
+
+	var result Result
+	require.NoError(t, Fix(file, c, &result))
+	require.Equal(t, []string{file}, result.Fixed)
+	require.False(t, c.DryRun.Empty())
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", string(content))
+}
+
+func TestPatchCreate(t *testing.T) {
+	var patch Patch
+	patch.Create("logo.png.license", 0o644, []byte("SPDX-License-Identifier: Apache-2.0\n"))
+
+	var out strings.Builder
+	require.NoError(t, patch.Encode(&out))
+	require.Contains(t, out.String(), "new file mode 100644\n")
+	require.Contains(t, out.String(), "--- /dev/null\n+++ b/logo.png.license\n@@ -0,0 +1 @@\n+SPDX-License-Identifier: Apache-2.0\n")
+}
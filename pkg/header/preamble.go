@@ -0,0 +1,170 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+)
+
+// PreambleRule declares the preamble of the files, i.e. the content at the start of the files that must stay
+// before the license header, like the Go build constraints, the encoding lines, or the YAML front matter.
+type PreambleRule struct {
+	// Languages and Paths select the files that the rule applies to, by the names of their languages,
+	// or the doublestar patterns of their paths.
+	Languages []string `yaml:"languages"`
+	Paths     []string `yaml:"paths"`
+	// Patterns are the regular expressions of the preamble, they are matched in order at the start of the lines,
+	// each can match several times in a row, and the blank lines between the matches are skipped.
+	Patterns []string `yaml:"patterns"`
+
+	patterns []*regexp.Regexp
+}
+
+// compile validates the rule and compiles the patterns of it.
+func (rule *PreambleRule) compile() error {
+	if len(rule.Languages) == 0 && len(rule.Paths) == 0 {
+		return errors.New("either languages or paths is required")
+	}
+	if len(rule.Patterns) == 0 {
+		return errors.New("patterns is required")
+	}
+	for _, language := range rule.Languages {
+		if !comments.LanguageExists(language) {
+			return fmt.Errorf("unknown language %q", language)
+		}
+	}
+	for _, pattern := range rule.Paths {
+		if err := validatePattern(pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q in paths: %w", pattern, err)
+		}
+	}
+	rule.patterns = nil
+	for _, pattern := range rule.Patterns {
+		p, err := regexp.Compile(`\A(?:` + pattern + `)`)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q in patterns: %w", pattern, err)
+		}
+		rule.patterns = append(rule.patterns, p)
+	}
+	return nil
+}
+
+// appliesTo tells whether the rule applies to the file.
+func (rule *PreambleRule) appliesTo(file string) (bool, error) {
+	if language, ok := comments.FileLanguage(file); ok && slices.Contains(rule.Languages, language) {
+		return true, nil
+	}
+	return tryMatchPatten(file, rule.Paths)
+}
+
+// preambles returns the patterns of the preamble rules applying to the file, in order.
+func (config *ConfigHeader) preambles(file string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for i := range config.Preambles {
+		applies, err := config.Preambles[i].appliesTo(file)
+		if err != nil {
+			return nil, err
+		}
+		if applies {
+			patterns = append(patterns, config.Preambles[i].patterns...)
+		}
+	}
+	return patterns, nil
+}
+
+// trimPreamble trims the preamble of the file content, if any preamble rule applies to the file.
+func (config *ConfigHeader) trimPreamble(file string, content []byte) ([]byte, error) {
+	preambles, err := config.preambles(file)
+	if err != nil || len(preambles) == 0 {
+		return content, err
+	}
+	if end, ok := preambleEnd(comments.FileCommentStyle(file), content, preambles); ok {
+		return content[end:], nil
+	}
+	return content, nil
+}
+
+// preambleEnd returns the end of the preamble of the content, i.e. the content matched by the after of the
+// comment style at the start of the content, if any, and then the preamble patterns. It returns false if none
+// of the preamble patterns matches, in which case the license header is placed as the comment style says.
+func preambleEnd(style *comments.CommentStyle, content []byte, patterns []*regexp.Regexp) (int, bool) {
+	if len(patterns) == 0 {
+		return 0, false
+	}
+
+	pos := 0
+	if style != nil && style.After != "" {
+		if loc := regexp.MustCompile(style.After).FindIndex(content); loc != nil && loc[0] == 0 {
+			pos = nextLine(content, loc[1])
+		}
+	}
+
+	matched := false
+	for _, pattern := range patterns {
+		for {
+			start := skipBlankLines(content, pos)
+			loc := pattern.FindIndex(content[start:])
+			if loc == nil || loc[1] == 0 {
+				break
+			}
+			pos, matched = nextLine(content, start+loc[1]), true
+		}
+	}
+	return pos, matched
+}
+
+// nextLine returns the start of the line after the one that the position is in, or the end of the content,
+// the position is returned as is if it's at the start of a line already, e.g. the match ends with a line break.
+func nextLine(content []byte, pos int) int {
+	if pos > 0 && content[pos-1] == '\n' {
+		return pos
+	}
+	if i := bytes.IndexByte(content[pos:], '\n'); i >= 0 {
+		return pos + i + 1
+	}
+	return len(content)
+}
+
+// skipBlankLines returns the start of the first non-blank line from the position, which is at the start of a line.
+func skipBlankLines(content []byte, pos int) int {
+	for pos < len(content) {
+		i := bytes.IndexByte(content[pos:], '\n')
+		if i < 0 || len(bytes.TrimSpace(content[pos:pos+i])) > 0 {
+			break
+		}
+		pos += i + 1
+	}
+	return pos
+}
+
+// insertAfterPreamble inserts the license header after the preamble that ends at the position,
+// separated from the preamble by a blank line.
+func insertAfterPreamble(content []byte, end int, licenseHeader string) []byte {
+	fixed := slices.Clone(content[:end])
+	if !bytes.HasSuffix(fixed, []byte("\n")) {
+		fixed = append(fixed, '\n')
+	}
+	fixed = append(append(fixed, '\n'), licenseHeader...)
+	return append(fixed, content[skipBlankLines(content, end):]...)
+}
@@ -0,0 +1,117 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/assets"
+)
+
+func TestFixWithPreambles(t *testing.T) {
+	config := &ConfigHeader{
+		License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"},
+		Preambles: []PreambleRule{
+			{Languages: []string{"Go"}, Patterns: []string{`//go:build .*`, `// \+build .*`}},
+			{Languages: []string{"Dockerfile"}, Patterns: []string{`# *syntax=.*`, `# *escape=.*`}},
+			{Paths: []string{"**/*.md"}, Patterns: []string{`(?s)---\n.*?\n---\n`}},
+		},
+	}
+	require.NoError(t, config.Finalize())
+
+	frontMatter := "---\ntitle: " + strings.Repeat("a very long title ", 10) + "\n---\n"
+	tests := []struct {
+		name     string
+		content  string
+		preamble string
+		rest     string
+	}{
+		{
+			name:     "build.go",
+			content:  "//go:build linux\n// +build linux\n\npackage main\n",
+			preamble: "//go:build linux\n// +build linux\n\n",
+			rest:     "package main\n",
+		},
+		{
+			name:     "plain.go",
+			content:  "package main\n",
+			preamble: "",
+			rest:     "package main\n",
+		},
+		{
+			name:     "Dockerfile",
+			content:  "# syntax=docker/dockerfile:1\n# escape=`\nFROM alpine\n",
+			preamble: "# syntax=docker/dockerfile:1\n# escape=`\n\n",
+			rest:     "FROM alpine\n",
+		},
+		{
+			name:     "docs/index.md",
+			content:  frontMatter + "\n# Title\n",
+			preamble: frontMatter + "\n",
+			rest:     "# Title\n",
+		},
+	}
+	dir := t.TempDir()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(dir, test.name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+			require.NoError(t, os.WriteFile(file, []byte(test.content), 0o600))
+
+			var result Result
+			require.NoError(t, CheckFile(file, config, &result))
+			require.Equal(t, []string{file}, result.Failure)
+			require.NoError(t, Fix(file, config, &result))
+
+			fixed, err := os.ReadFile(file)
+			require.NoError(t, err)
+			header := getLicenseHeaderCustomConfig(test.name, t.Error, config)
+			require.Equal(t, test.preamble+header+test.rest, string(fixed))
+
+			result = Result{}
+			require.NoError(t, CheckFile(file, config, &result))
+			require.Equal(t, []string{file}, result.Success, "the threshold should be measured after the preamble")
+
+			require.NoError(t, Remove(file, config, &result))
+			removed, err := os.ReadFile(file)
+			require.NoError(t, err)
+			require.Equal(t, test.preamble+test.rest, string(removed))
+		})
+	}
+
+	t.Run("migrate", func(t *testing.T) {
+		file := filepath.Join(dir, "docs/mit.md")
+		mit, err := assets.Asset("lcs-templates/MIT.txt")
+		require.NoError(t, err)
+		header := "<!--\n" + strings.NewReplacer("[year]", "2020", "[owner]", "Bar").Replace(string(mit)) + "-->\n\n"
+		require.NoError(t, os.WriteFile(file, []byte(frontMatter+"\n"+header+"# Title\n"), 0o600))
+
+		var result Result
+		replaced, err := Migrate(file, config, &result)
+		require.NoError(t, err)
+		require.Equal(t, "MIT", replaced)
+		migrated, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, frontMatter+"\n"+getLicenseHeaderCustomConfig("mit.md", t.Error, config)+"# Title\n", string(migrated))
+	})
+}
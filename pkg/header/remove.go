@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+// Remove removes the configured license header from the file, that is, the whole comment block
+// that contains the license header, and the blank lines after it, the content that the license
+// header is placed after, e.g. shebang and the preamble, is kept. The files licensed by their sidecar files or
+// the REUSE.toml are left as they are, and the cell of the license header is removed from the Jupyter notebooks.
+func Remove(file string, config *ConfigHeader, result *Result) error {
+	if reuse, err := config.licensedByREUSE(file); err != nil {
+		return err
+	} else if reuse {
+		logger.Log.Infoln("The file is licensed by its sidecar file or REUSE.toml, which is kept:", file)
+		return nil
+	}
+	if isNotebook(file) {
+		return removeNotebookCell(file, config, result)
+	}
+
+	style := comments.FileCommentStyle(file)
+	if style == nil {
+		return fmt.Errorf("unsupported file: %v", file)
+	}
+
+	stat, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	content, enc := decode(raw)
+
+	offset, base, err := config.headerStart(file, style, string(content))
+	if err != nil {
+		return err
+	}
+	start, end := -1, -1
+	for _, alternative := range config.alternatives() {
+		ctx := alternative.FileContext(file)
+		start, end = findComment(style, string(content), offset, base, alternative.LicenseLocationThreshold, func(block string) bool {
+			return matches(block, lcs.NormalizeHeader(block), alternative, ctx)
+		})
+		if start >= 0 {
+			break
+		}
+	}
+	if start < 0 {
+		logger.Log.Debugln("No license header to remove:", file)
+		return nil
+	}
+	for end < len(content) {
+		line, next := lineAt(string(content), end)
+		if strings.TrimSpace(line) != "" {
+			break
+		}
+		end = next
+	}
+	removed := append(content[:start:start], content[end:]...)
+
+	if err := writeFixed(file, stat.Mode(), raw, enc.encode(removed), config); err != nil {
+		return err
+	}
+
+	result.Remove(file)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemove(t *testing.T) {
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"}}
+	require.NoError(t, c.Finalize())
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "main.go",
+			content:  getLicenseHeaderCustomConfig("main.go", t.Error, c) + "package main\n",
+			expected: "package main\n",
+		},
+		{
+			name:     "Main.java",
+			content:  getLicenseHeaderCustomConfig("Main.java", t.Error, c) + "\n\npackage main;\n",
+			expected: "package main;\n",
+		},
+		{
+			name:     "main.py",
+			content:  "#!/usr/bin/env python3\n" + getLicenseHeaderCustomConfig("main.py", t.Error, c) + "print(1)\n",
+			expected: "#!/usr/bin/env python3\nprint(1)\n",
+		},
+		{
+			name:     "doc.go",
+			content:  "//go:build linux\n\n" + getLicenseHeaderCustomConfig("doc.go", t.Error, c) + "// Package main does nothing.\npackage main\n",
+			expected: "//go:build linux\n\n// Package main does nothing.\npackage main\n",
+		},
+		{
+			name:     "missing.go",
+			content:  "// Package main does nothing.\npackage main\n",
+			expected: "// Package main does nothing.\npackage main\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), test.name)
+			require.NoError(t, os.WriteFile(file, []byte(test.content), 0o600))
+
+			var result Result
+			require.NoError(t, Remove(file, c, &result))
+
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, string(content))
+			require.Equal(t, test.content != test.expected, len(result.Removed) == 1)
+		})
+	}
+}
@@ -19,42 +19,166 @@ package header
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 )
 
+// Reason is the reason why a file fails the check.
+type Reason string
+
+const (
+	// InvalidHeader means the file doesn't have a valid license header.
+	InvalidHeader Reason = "invalid-license-header"
+	// StaleYear means the file has a valid license header, but the copyright year in it is outdated.
+	StaleYear Reason = "stale-copyright-year"
+)
+
+// SkipReason is the reason why a file is skipped, that is, it's neither checked nor ignored by the config.
+type SkipReason string
+
+const (
+	// BinaryFile means the file is not a text file, which cannot have a license header.
+	BinaryFile SkipReason = "binary"
+	// UnsupportedStyle means the comment style of the file is unknown, so its license header cannot be fixed.
+	UnsupportedStyle SkipReason = "unsupported-comment-style"
+	// TooLarge means the file is larger than the configured max-file-size.
+	TooLarge SkipReason = "too-large"
+)
+
+// IgnoreReason is the reason why a file is ignored other than by the paths-ignore of the config.
+type IgnoreReason string
+
+const (
+	// Generated means the file is generated, which is ignored in the skip-generated mode.
+	Generated IgnoreReason = "generated"
+)
+
 type Result struct {
 	mu      sync.Mutex
 	Success []string
 	Failure []string
 	Ignored []string
-	Fixed   []string
+	// IgnoreReasons are the reasons of the files in Ignored, except for the ones ignored by the paths-ignore.
+	IgnoreReasons map[string]IgnoreReason
+	Fixed         []string
+	// Removed are the files whose license headers are removed.
+	Removed []string
+	// Skipped are the files that are not checked or fixed, with the reasons in SkipReasons.
+	Skipped     []string
+	SkipReasons map[string]SkipReason
+	// Reasons are the reasons of the files in Failure, except for the ones of InvalidHeader.
+	Reasons map[string]Reason
+	// Licenses are the accepted licenses that the files in Success matched.
+	Licenses map[string]string
 }
 
 func (result *Result) Fail(file string) {
+	result.FailWithReason(file, InvalidHeader)
+}
+
+// FailWithReason marks the file as invalid for the given reason.
+func (result *Result) FailWithReason(file string, reason Reason) {
 	result.mu.Lock()
 	result.Failure = append(result.Failure, file)
+	if reason != InvalidHeader {
+		if result.Reasons == nil {
+			result.Reasons = make(map[string]Reason)
+		}
+		result.Reasons[file] = reason
+	}
 	result.mu.Unlock()
 }
 
+// Reason returns the reason why the file fails the check.
+func (result *Result) Reason(file string) Reason {
+	result.mu.Lock()
+	defer result.mu.Unlock()
+	if reason, ok := result.Reasons[file]; ok {
+		return reason
+	}
+	return InvalidHeader
+}
+
 func (result *Result) Succeed(file string) {
+	result.SucceedWith(file, "")
+}
+
+// SucceedWith marks the file as valid for matching the accepted license, which can be empty if it's unknown.
+func (result *Result) SucceedWith(file, license string) {
 	result.mu.Lock()
 	result.Success = append(result.Success, file)
+	if license != "" {
+		if result.Licenses == nil {
+			result.Licenses = make(map[string]string)
+		}
+		result.Licenses[file] = license
+	}
 	result.mu.Unlock()
 }
 
+// License returns the accepted license that the file matched, or an empty string if it's unknown.
+func (result *Result) License(file string) string {
+	result.mu.Lock()
+	defer result.mu.Unlock()
+	return result.Licenses[file]
+}
+
 func (result *Result) Ignore(file string) {
 	result.mu.Lock()
 	result.Ignored = append(result.Ignored, file)
 	result.mu.Unlock()
 }
 
+// IgnoreWithReason marks the file as ignored for the given reason.
+func (result *Result) IgnoreWithReason(file string, reason IgnoreReason) {
+	result.mu.Lock()
+	result.Ignored = append(result.Ignored, file)
+	if result.IgnoreReasons == nil {
+		result.IgnoreReasons = make(map[string]IgnoreReason)
+	}
+	result.IgnoreReasons[file] = reason
+	result.mu.Unlock()
+}
+
+// IgnoreReason returns the reason why the file is ignored, or an empty string if it's ignored by the paths-ignore
+// or not ignored.
+func (result *Result) IgnoreReason(file string) IgnoreReason {
+	result.mu.Lock()
+	defer result.mu.Unlock()
+	return result.IgnoreReasons[file]
+}
+
 func (result *Result) Fix(file string) {
 	result.mu.Lock()
 	result.Fixed = append(result.Fixed, file)
 	result.mu.Unlock()
 }
 
+func (result *Result) Remove(file string) {
+	result.mu.Lock()
+	result.Removed = append(result.Removed, file)
+	result.mu.Unlock()
+}
+
+// Skip marks the file as skipped for the given reason.
+func (result *Result) Skip(file string, reason SkipReason) {
+	result.mu.Lock()
+	result.Skipped = append(result.Skipped, file)
+	if result.SkipReasons == nil {
+		result.SkipReasons = make(map[string]SkipReason)
+	}
+	result.SkipReasons[file] = reason
+	result.mu.Unlock()
+}
+
+// SkipReason returns the reason why the file is skipped, or an empty string if it's not skipped.
+func (result *Result) SkipReason(file string) SkipReason {
+	result.mu.Lock()
+	defer result.mu.Unlock()
+	return result.SkipReasons[file]
+}
+
 func (result *Result) HasFailure() bool {
 	result.mu.Lock()
 	has := len(result.Failure) > 0
@@ -74,14 +198,27 @@ func (result *Result) Error() error {
 
 func (result *Result) String() string {
 	result.mu.Lock()
+	// The files that fail the check but cannot be fixed are skipped by fix, don't count them twice.
+	skipped := 0
+	for _, file := range result.Skipped {
+		if !slices.Contains(result.Failure, file) {
+			skipped++
+		}
+	}
 	s := fmt.Sprintf(
 		"Totally checked %d files, valid: %d, invalid: %d, ignored: %d, fixed: %d",
-		len(result.Success)+len(result.Failure)+len(result.Ignored),
+		len(result.Success)+len(result.Failure)+len(result.Ignored)+skipped,
 		len(result.Success),
 		len(result.Failure),
 		len(result.Ignored),
 		len(result.Fixed),
 	)
+	if len(result.Skipped) > 0 {
+		s += fmt.Sprintf(", skipped: %d", len(result.Skipped))
+	}
+	if len(result.Removed) > 0 {
+		s += fmt.Sprintf(", removed: %d", len(result.Removed))
+	}
 	result.mu.Unlock()
 	return s
 }
@@ -0,0 +1,319 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/bmatcuk/doublestar/v2"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+const (
+	// sidecarSuffix is the suffix of the sidecar files that hold the licensing information of the files,
+	// which cannot have license headers, e.g. binary files.
+	sidecarSuffix = ".license"
+	reuseTOMLFile = "REUSE.toml"
+	reuseDep5File = ".reuse/dep5"
+)
+
+// reuseAnnotation is the licensing information of some paths declared in REUSE.toml or .reuse/dep5.
+type reuseAnnotation struct {
+	match      func(path string) bool
+	license    string
+	copyrights []string
+}
+
+// reuseInfo is the licensing information in REUSE.toml and .reuse/dep5, see https://reuse.software/spec/.
+type reuseInfo struct {
+	annotations []*reuseAnnotation
+}
+
+// loadREUSE loads the licensing information in the REUSE.toml, or the legacy .reuse/dep5, under the dir.
+func loadREUSE(dir string) (*reuseInfo, error) {
+	info := &reuseInfo{}
+
+	if bs, err := os.ReadFile(filepath.Join(dir, reuseTOMLFile)); err == nil {
+		return info, info.parseTOML(bs)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if bs, err := os.ReadFile(filepath.Join(dir, reuseDep5File)); err == nil {
+		return info, info.parseDep5(bs)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func (info *reuseInfo) parseTOML(bs []byte) error {
+	var file struct {
+		Annotations []struct {
+			Path      any `toml:"path"`
+			Copyright any `toml:"SPDX-FileCopyrightText"`
+			License   any `toml:"SPDX-License-Identifier"`
+		} `toml:"annotations"`
+	}
+	if _, err := toml.Decode(string(bs), &file); err != nil {
+		return fmt.Errorf("failed to parse %v: %w", reuseTOMLFile, err)
+	}
+
+	for _, annotation := range file.Annotations {
+		patterns := tomlStrings(annotation.Path)
+		info.annotations = append(info.annotations, &reuseAnnotation{
+			match: func(path string) bool {
+				for _, pattern := range patterns {
+					if m, _ := doublestar.Match(pattern, path); m {
+						return true
+					}
+				}
+				return false
+			},
+			license:    strings.Join(tomlStrings(annotation.License), " AND "),
+			copyrights: tomlStrings(annotation.Copyright),
+		})
+	}
+	return nil
+}
+
+// tomlStrings returns the value that is either a string or an array of strings as a slice.
+func tomlStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// parseDep5 parses the machine-readable Debian copyright file, see
+// https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/.
+func (info *reuseInfo) parseDep5(bs []byte) error {
+	var paragraph map[string]string
+	var field string
+	flush := func() {
+		if files, ok := paragraph["Files"]; ok {
+			var patterns []*regexp.Regexp
+			for _, pattern := range strings.Fields(files) {
+				// In the Debian copyright file, "*" matches any characters including "/", and "?" matches a single one.
+				pattern = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(pattern))
+				patterns = append(patterns, regexp.MustCompile("^"+pattern+"$"))
+			}
+			info.annotations = append(info.annotations, &reuseAnnotation{
+				match: func(path string) bool {
+					for _, pattern := range patterns {
+						if pattern.MatchString(path) {
+							return true
+						}
+					}
+					return false
+				},
+				license:    strings.TrimSpace(strings.SplitN(paragraph["License"], "\n", 2)[0]),
+				copyrights: strings.Split(paragraph["Copyright"], "\n"),
+			})
+		}
+		paragraph, field = make(map[string]string), ""
+	}
+
+	flush()
+	scanner := bufio.NewScanner(bytes.NewReader(bs))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case line[0] == ' ' || line[0] == '\t': // continuation of the previous field
+			if field != "" {
+				paragraph[field] += "\n" + strings.TrimSpace(line)
+			}
+		default:
+			name, value, found := strings.Cut(line, ":")
+			if !found {
+				return fmt.Errorf("failed to parse %v, invalid line: %q", reuseDep5File, line)
+			}
+			field = strings.TrimSpace(name)
+			paragraph[field] = strings.TrimSpace(value)
+		}
+	}
+	flush()
+	return scanner.Err()
+}
+
+// annotation returns the licensing information of the file, the last matched annotation wins.
+func (info *reuseInfo) annotation(file string) *reuseAnnotation {
+	if info == nil {
+		return nil
+	}
+	path := filepath.ToSlash(filepath.Clean(file))
+	for i := len(info.annotations) - 1; i >= 0; i-- {
+		if info.annotations[i].match(path) {
+			return info.annotations[i]
+		}
+	}
+	return nil
+}
+
+// satisfies tells whether the annotation is the licensing information of the configured license, that is, the
+// annotated license allows using the configured spdx-id, and one of the copyrights is of the configured owner.
+func (annotation *reuseAnnotation) satisfies(config *ConfigHeader) bool {
+	if spdxID := config.License.SpdxID; spdxID != "" {
+		if compatible, err := spdxCompatible(annotation.license, spdxID); err != nil || !compatible {
+			return false
+		}
+	}
+	if owner := config.License.CopyrightOwner; owner != "" {
+		for _, copyright := range annotation.copyrights {
+			if strings.Contains(copyright, owner) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// isREUSEMetadata tells whether the file holds the licensing information of other files in the REUSE mode,
+// which doesn't need a license itself.
+func isREUSEMetadata(file string) bool {
+	path := filepath.ToSlash(filepath.Clean(file))
+	if path == reuseTOMLFile || path == reuseDep5File || strings.HasPrefix(path, "LICENSES/") {
+		return true
+	}
+	if strings.HasSuffix(path, sidecarSuffix) {
+		_, err := os.Stat(strings.TrimSuffix(file, sidecarSuffix))
+		return err == nil
+	}
+	return false
+}
+
+// checkREUSE checks the licensing information of the file in the REUSE mode, or of the binary-paths, that is in its
+// sidecar file or the REUSE.toml (.reuse/dep5), done is false if there isn't such information, and the file should be
+// checked as usual.
+func checkREUSE(file string, config *ConfigHeader, result *Result) (done bool, err error) {
+	if isREUSEMetadata(file) {
+		result.Ignore(file)
+		return true, nil
+	}
+
+	bs, err := os.ReadFile(file + sidecarSuffix)
+	if err == nil {
+		for i, alternative := range config.alternatives() {
+			if sidecarSatisfies(string(bs), alternative, alternative.FileContext(file)) {
+				result.SucceedWith(file, config.licenseName(i))
+				return true, nil
+			}
+		}
+		logger.Log.Debugln("The sidecar file doesn't have a valid license:", file+sidecarSuffix)
+		result.Fail(file)
+		return true, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return true, err
+	}
+
+	if annotation := config.reuse.annotation(file); annotation != nil {
+		for i, alternative := range config.alternatives() {
+			if annotation.satisfies(alternative) {
+				result.SucceedWith(file, config.licenseName(i))
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// licensedByREUSE tells whether the file passes the check by the licensing information in its sidecar file or
+// the REUSE.toml (.reuse/dep5), rather than by its license header.
+func (config *ConfigHeader) licensedByREUSE(file string) (bool, error) {
+	required, err := config.requiresLicensingInfo(file)
+	if err != nil || !required {
+		return false, err
+	}
+	var result Result
+	done, err := checkREUSE(file, config, &result)
+	return done && len(result.Success) > 0, err
+}
+
+// sidecarSatisfies tells whether the content of the sidecar file has the configured license,
+// either in the configured form, or in the SPDX short-form if the spdx-id is configured.
+func sidecarSatisfies(content string, config *ConfigHeader, ctx *FileContext) bool {
+	if matches(content, lcs.NormalizeHeader(content), config, ctx) {
+		return true
+	}
+	return config.License.SpdxID != "" && checkSPDX(content, config) == nil
+}
+
+// needsSidecar tells whether the file cannot have a license header and needs a sidecar file in the REUSE mode,
+// or it already has a sidecar file, which takes precedence over its license header.
+func needsSidecar(file string, style *comments.CommentStyle) (bool, error) {
+	if _, err := os.Stat(file + sidecarSuffix); err == nil || style == nil {
+		return true, nil
+	}
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	text, _ := decode(bs)
+	return !strings.HasPrefix(http.DetectContentType(text), "text/"), nil
+}
+
+// CreateSidecar creates the sidecar file with the configured license for the file, which cannot have a license header,
+// the license is in the SPDX short-form if the spdx-id is configured, as REUSE requires.
+func CreateSidecar(file string, config *ConfigHeader, result *Result) error {
+	sidecar := file + sidecarSuffix
+	if _, err := os.Stat(sidecar); err == nil {
+		return fmt.Errorf("the sidecar file doesn't have a valid license, please fix it manually: %v", sidecar)
+	}
+
+	c := *config
+	if c.License.SpdxID != "" {
+		c.License.Form = SPDXForm
+	}
+	content := []byte(strings.TrimSpace(c.LicenseContent(c.FileContext(file))) + "\n")
+
+	if config.DryRun != nil {
+		config.DryRun.Create(sidecar, 0o644, content)
+	} else if err := os.WriteFile(sidecar, content, 0o644); err != nil { //nolint:gosec // sidecar of a file from tool's own file scanner
+		return err
+	}
+
+	result.Fix(file)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadREUSE(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "REUSE.toml"), []byte(`version = 1
+
+[[annotations]]
+path = ["images/**", "*.png"]
+SPDX-FileCopyrightText = "2026 Foo"
+SPDX-License-Identifier = "Apache-2.0"
+
+[[annotations]]
+path = "images/third-party/**"
+SPDX-FileCopyrightText = ["2020 Bar", "2021 Baz"]
+SPDX-License-Identifier = ["MIT", "CC0-1.0"]
+`), 0o600))
+
+	info, err := loadREUSE(dir)
+	require.NoError(t, err)
+	require.Equal(t, "Apache-2.0", info.annotation("images/logo.svg").license)
+	require.Equal(t, "Apache-2.0", info.annotation("logo.png").license)
+	require.Nil(t, info.annotation("docs/logo.png"), "* doesn't match /")
+	require.Equal(t, "MIT AND CC0-1.0", info.annotation("images/third-party/icon.svg").license)
+	require.Equal(t, []string{"2020 Bar", "2021 Baz"}, info.annotation("images/third-party/icon.svg").copyrights)
+
+	dep5 := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dep5, ".reuse"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dep5, ".reuse", "dep5"), []byte(`Format: https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/
+Upstream-Name: foo
+
+Files: images/* *.png
+Copyright: 2026 Foo
+License: Apache-2.0
+
+Files: images/third-party/*
+Copyright: 2020 Bar
+ 2021 Baz
+License: MIT
+`), 0o600))
+
+	info, err = loadREUSE(dep5)
+	require.NoError(t, err)
+	require.Equal(t, "Apache-2.0", info.annotation("images/logo.svg").license)
+	require.Equal(t, "Apache-2.0", info.annotation("docs/logo.png").license, "* matches / in dep5")
+	require.Equal(t, "MIT", info.annotation("images/third-party/icon.svg").license)
+	require.Equal(t, []string{"2020 Bar", "2021 Baz"}, info.annotation("images/third-party/icon.svg").copyrights)
+	require.Nil(t, info.annotation("main.go"))
+}
+
+func TestCheckAndFixREUSE(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	files := map[string][]byte{
+		"REUSE.toml":              []byte("version = 1\n\n[[annotations]]\npath = \"vendor/**\"\nSPDX-FileCopyrightText = \"2026 Foo\"\nSPDX-License-Identifier = \"Apache-2.0\"\n"),
+		"logo.png":                png,
+		"icon.png":                png,
+		"icon.png.license":        []byte("SPDX-FileCopyrightText: 2026 Foo\nSPDX-License-Identifier: Apache-2.0\n"),
+		"mit.png":                 png,
+		"mit.png.license":         []byte("SPDX-FileCopyrightText: 2026 Foo\nSPDX-License-Identifier: MIT\n"),
+		"vendor/lib.js":           []byte("console.log(1)\n"),
+		"LICENSES/Apache-2.0.txt": []byte("Apache License\n"),
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, content, 0o600))
+	}
+
+	c := &ConfigHeader{
+		License:  LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026"},
+		Licenses: []LicenseConfig{{SpdxID: "MIT", CopyrightOwner: "Foo", CopyrightYear: "2026"}},
+		REUSE:    true,
+	}
+	require.NoError(t, c.Finalize())
+
+	check := func() *Result {
+		var result Result
+		for _, file := range []string{"REUSE.toml", "logo.png", "icon.png", "icon.png.license", "mit.png", "vendor/lib.js", "LICENSES/Apache-2.0.txt"} {
+			require.NoError(t, CheckFile(file, c, &result))
+		}
+		return &result
+	}
+
+	result := check()
+	require.ElementsMatch(t, []string{"icon.png", "mit.png", "vendor/lib.js"}, result.Success)
+	require.Equal(t, map[string]string{"icon.png": "Apache-2.0", "mit.png": "MIT", "vendor/lib.js": "Apache-2.0"}, result.Licenses)
+	require.ElementsMatch(t, []string{"logo.png"}, result.Failure)
+	require.ElementsMatch(t, []string{"REUSE.toml", "icon.png.license", "LICENSES/Apache-2.0.txt"}, result.Ignored)
+
+	require.NoError(t, Fix("logo.png", c, result))
+	sidecar, err := os.ReadFile("logo.png.license")
+	require.NoError(t, err)
+	require.Equal(t, "SPDX-FileCopyrightText: 2026 Foo\nSPDX-License-Identifier: Apache-2.0\n", string(sidecar))
+
+	result = check()
+	require.False(t, result.HasFailure())
+
+	for _, file := range result.Success {
+		require.NoError(t, Remove(file, c, result), "the sidecar files and REUSE.toml are kept")
+	}
+	require.Empty(t, result.Removed)
+	_, err = os.Stat("logo.png.license")
+	require.NoError(t, err)
+}
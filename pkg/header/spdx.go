@@ -0,0 +1,225 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// HeaderForm is the form of the license headers.
+type HeaderForm string
+
+const (
+	// FullForm is the license notice text, e.g. "Licensed under the Apache License...", which is the default.
+	FullForm HeaderForm = "full"
+	// SPDXForm is the SPDX short-form identifiers, "SPDX-FileCopyrightText" and "SPDX-License-Identifier".
+	SPDXForm HeaderForm = "spdx"
+)
+
+// spdxTagEnd matches the end of the line where the SPDX tag is, including the possible comment end.
+const spdxTagEnd = `[ \t]*(?:\*/|-->|\*\)|-\}|%>|#\})?[ \t\r]*$`
+
+var (
+	spdxLicenseTag   = regexp.MustCompile(`(?m)SPDX-License-Identifier:[ \t]*(.*?)` + spdxTagEnd)
+	spdxCopyrightTag = regexp.MustCompile(`(?m)SPDX-FileCopyrightText:[ \t]*(.*?)` + spdxTagEnd)
+	spdxToken        = regexp.MustCompile(`\(|\)|[^\s()]+`)
+)
+
+func (form HeaderForm) validate() error {
+	switch form {
+	case "", FullForm, SPDXForm:
+		return nil
+	}
+	return fmt.Errorf("unsupported license header form %q, supported forms are %v", form, []HeaderForm{FullForm, SPDXForm})
+}
+
+// spdxContent returns the SPDX short-form license header, with the "[year]" and "[owner]" placeholders.
+func spdxContent(license *LicenseConfig) string {
+	copyright := "SPDX-FileCopyrightText: [year]"
+	if license.CopyrightOwner != "" {
+		copyright += " [owner]"
+	}
+	return copyright + "\nSPDX-License-Identifier: " + license.SpdxID
+}
+
+// checkSPDX checks the SPDX short-form license header in the content, the SPDX-License-Identifier
+// must be found within the license-location-threshold, and its license expression must allow using
+// the configured spdx-id, if the copyright owner is configured, there must be a SPDX-FileCopyrightText
+// of the owner too. The returned error explains why the check fails.
+func checkSPDX(content string, config *ConfigHeader) error {
+	m := spdxLicenseTag.FindStringSubmatchIndex(content)
+	if m == nil {
+		return fmt.Errorf("no SPDX-License-Identifier is found")
+	}
+	if offset := len(lcs.NormalizeHeader(content[:m[0]])); offset >= config.LicenseLocationThreshold {
+		return fmt.Errorf("SPDX-License-Identifier is found at normalized offset %d, which exceeds the license-location-threshold %d",
+			offset, config.LicenseLocationThreshold)
+	}
+
+	expression := content[m[2]:m[3]]
+	compatible, err := spdxCompatible(expression, config.License.SpdxID)
+	if err != nil {
+		return err
+	}
+	if !compatible {
+		return fmt.Errorf("SPDX-License-Identifier %q doesn't allow using %q", expression, config.License.SpdxID)
+	}
+
+	if owner := config.License.CopyrightOwner; owner != "" {
+		for _, tag := range spdxCopyrightTag.FindAllStringSubmatch(content, -1) {
+			if strings.Contains(tag[1], owner) {
+				return nil
+			}
+		}
+		return fmt.Errorf("no SPDX-FileCopyrightText of %q is found", owner)
+	}
+	return nil
+}
+
+// spdxCompatible tells whether the license expression allows using the licenses of the expected expression,
+// that is, one of the choices of the expression is exactly one of the choices of the expected one, for example,
+// "MIT OR Apache-2.0" allows using "Apache-2.0", while "MIT AND Apache-2.0" doesn't.
+func spdxCompatible(expression, expected string) (bool, error) {
+	choices, err := parseSPDX(expression)
+	if err != nil {
+		return false, err
+	}
+	expectedChoices, err := parseSPDX(expected)
+	if err != nil {
+		return false, err
+	}
+	for choice := range choices {
+		if expectedChoices[choice] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseSPDX parses the SPDX license expression into the choices of licenses, each choice is the licenses
+// that must be all used, joined by " AND ", the license ids are case-insensitive, so they're in lower case.
+func parseSPDX(expression string) (map[string]bool, error) {
+	p := &spdxParser{expression: expression, tokens: spdxToken.FindAllString(expression, -1)}
+	choices, err := p.or()
+	if err == nil && p.pos < len(p.tokens) {
+		err = p.errorf("unexpected %q", p.tokens[p.pos])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(choices))
+	for _, choice := range choices {
+		sort.Strings(choice)
+		result[strings.Join(choice, " AND ")] = true
+	}
+	return result, nil
+}
+
+// spdxParser parses the SPDX license expression, whose grammar is
+//
+//	or     = and { "OR" and }
+//	and    = with { "AND" with }
+//	with   = "(" or ")" | id [ "WITH" id ]
+type spdxParser struct {
+	expression string
+	tokens     []string
+	pos        int
+}
+
+func (p *spdxParser) or() ([][]string, error) {
+	choices, err := p.and()
+	for err == nil && p.accept("OR") {
+		var more [][]string
+		if more, err = p.and(); err == nil {
+			choices = append(choices, more...)
+		}
+	}
+	return choices, err
+}
+
+func (p *spdxParser) and() ([][]string, error) {
+	choices, err := p.with()
+	for err == nil && p.accept("AND") {
+		var others [][]string
+		if others, err = p.with(); err != nil {
+			break
+		}
+		var product [][]string
+		for _, choice := range choices {
+			for _, other := range others {
+				product = append(product, append(append([]string{}, choice...), other...))
+			}
+		}
+		choices = product
+	}
+	return choices, err
+}
+
+func (p *spdxParser) with() ([][]string, error) {
+	if p.accept("(") {
+		choices, err := p.or()
+		if err == nil && !p.accept(")") {
+			err = p.errorf("missing %q", ")")
+		}
+		return choices, err
+	}
+
+	id, err := p.id()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("WITH") {
+		exception, err := p.id()
+		if err != nil {
+			return nil, err
+		}
+		id += " with " + exception
+	}
+	return [][]string{{id}}, nil
+}
+
+func (p *spdxParser) id() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", p.errorf("missing license id")
+	}
+	token := p.tokens[p.pos]
+	switch strings.ToUpper(token) {
+	case "(", ")", "AND", "OR", "WITH":
+		return "", p.errorf("unexpected %q", token)
+	}
+	p.pos++
+	return strings.ToLower(token), nil
+}
+
+func (p *spdxParser) accept(token string) bool {
+	if p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], token) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *spdxParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid SPDX license expression %q: %v", p.expression, fmt.Sprintf(format, args...))
+}
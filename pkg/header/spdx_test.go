@@ -0,0 +1,131 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPDXCompatible(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+		compatible bool
+		invalid    bool
+	}{
+		{expression: "Apache-2.0", expected: "Apache-2.0", compatible: true},
+		{expression: "apache-2.0", expected: "Apache-2.0", compatible: true},
+		{expression: "MIT OR Apache-2.0", expected: "Apache-2.0", compatible: true},
+		{expression: "(MIT OR Apache-2.0) AND BSD-3-Clause", expected: "Apache-2.0 AND BSD-3-Clause", compatible: true},
+		{expression: "MIT AND Apache-2.0", expected: "Apache-2.0"},
+		{expression: "GPL-2.0-only WITH Classpath-exception-2.0", expected: "GPL-2.0-only WITH Classpath-exception-2.0", compatible: true},
+		{expression: "GPL-2.0-only WITH Classpath-exception-2.0", expected: "GPL-2.0-only"},
+		{expression: "MIT", expected: "Apache-2.0"},
+		{expression: "MIT OR", expected: "MIT", invalid: true},
+		{expression: "(MIT", expected: "MIT", invalid: true},
+		{expression: "MIT Apache-2.0", expected: "MIT", invalid: true},
+		{expression: "", expected: "MIT", invalid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			compatible, err := spdxCompatible(test.expression, test.expected)
+			if test.invalid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.compatible, compatible)
+		})
+	}
+}
+
+func TestCheckSPDX(t *testing.T) {
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", Form: SPDXForm}}
+	require.NoError(t, c.Finalize())
+
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{
+			name:    "valid.go",
+			content: "// SPDX-FileCopyrightText: 2020 Foo\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+			valid:   true,
+		},
+		{
+			name:    "valid.c",
+			content: "/* SPDX-FileCopyrightText: 2020 Bar, 2021 Foo */\n/* SPDX-License-Identifier: MIT OR Apache-2.0 */\n",
+			valid:   true,
+		},
+		{
+			name:    "incompatible.go",
+			content: "// SPDX-FileCopyrightText: 2020 Foo\n// SPDX-License-Identifier: GPL-3.0-only\n\npackage main\n",
+		},
+		{
+			name:    "other-owner.go",
+			content: "// SPDX-FileCopyrightText: 2020 Bar\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+		},
+		{
+			name:    "missing.go",
+			content: "package main\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), test.name)
+			require.NoError(t, os.WriteFile(file, []byte(test.content), 0o600))
+
+			var result Result
+			require.NoError(t, CheckFile(file, c, &result))
+			require.Equal(t, test.valid, !result.HasFailure())
+
+			diff, err := DiffFile(file, c)
+			require.NoError(t, err)
+			require.Equal(t, test.valid, diff == "", diff)
+		})
+	}
+}
+
+func TestFixSPDX(t *testing.T) {
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Foo", CopyrightYear: "2026", Form: SPDXForm}}
+	require.NoError(t, c.Finalize())
+
+	for name, expected := range map[string]string{
+		"main.go":   "// SPDX-FileCopyrightText: 2026 Foo\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+		"main.py":   "# SPDX-FileCopyrightText: 2026 Foo\n# SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+		"Main.java": "/*\n * SPDX-FileCopyrightText: 2026 Foo\n * SPDX-License-Identifier: Apache-2.0\n */\n\npackage main\n",
+	} {
+		file := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(file, []byte("package main\n"), 0o600))
+
+		var result Result
+		require.NoError(t, Fix(file, c, &result))
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(content))
+
+		result = Result{}
+		require.NoError(t, CheckFile(file, c, &result))
+		require.False(t, result.HasFailure())
+	}
+}
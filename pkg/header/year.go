@@ -0,0 +1,189 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/skywalking-eyes/pkg/license"
+	"github.com/apache/skywalking-eyes/pkg/logger"
+)
+
+// YearPolicy decides how the copyright years in the existing license headers are maintained.
+type YearPolicy string
+
+const (
+	// PreserveYear leaves the copyright years in the existing license headers as they are, which is the default.
+	PreserveYear YearPolicy = "preserve"
+	// ExtendRange requires the copyright years to end with the current year, e.g. "2019" is extended to "2019-2026".
+	ExtendRange YearPolicy = "extend-range"
+	// GitLastModified requires the copyright years to end no earlier than the year when the file is last modified,
+	// which is the year of the last commit that changes the file, or the current year if the file is changed in the worktree.
+	GitLastModified YearPolicy = "git-last-modified"
+)
+
+// YearSource decides where the "[year]" placeholder of each file is resolved from.
+type YearSource string
+
+const (
+	// ConfigYear resolves the "[year]" of all the files from the copyright-year, or the current year if it's empty,
+	// which is the default.
+	ConfigYear YearSource = "config"
+	// GitFirstCommit resolves the "[year]" of each file from the year of the first commit that adds the file,
+	// or the current year if the file is not committed yet.
+	GitFirstCommit YearSource = "git-first-commit"
+)
+
+func (source YearSource) validate() error {
+	switch source {
+	case "", ConfigYear, GitFirstCommit:
+		return nil
+	}
+	return fmt.Errorf("unsupported copyright year source %q, supported sources are %v", source,
+		[]YearSource{ConfigYear, GitFirstCommit})
+}
+
+// yearPlaceholder takes the place of the "[year]" in the license content, so that the years can be matched
+// after the license content is normalized.
+const yearPlaceholder = "licenseeyecopyrightyears"
+
+var (
+	// normalizedYears matches the copyright years in the normalized license header, e.g. "2019", "2019-2023", "2019, 2021".
+	normalizedYears = `(\d{4}(?:\s*[-,]\s*\d{4})*)`
+	// copyrightYears matches the copyright years in the raw license header.
+	copyrightYears = regexp.MustCompile(`(?i)(copyright\w*\b[^\n\d]*?)(\d{4}(?:[ \t]*[-,][ \t]*\d{4})*)`)
+	// lastYear matches the last year of the copyright years, and the range separator before it, if any.
+	lastYear = regexp.MustCompile(`(\s*-\s*)?(\d{4})$`)
+)
+
+func (policy YearPolicy) validate() error {
+	switch policy {
+	case "", PreserveYear, ExtendRange, GitLastModified:
+		return nil
+	}
+	return fmt.Errorf("unsupported copyright year policy %q, supported policies are %v", policy,
+		[]YearPolicy{PreserveYear, ExtendRange, GitLastModified})
+}
+
+// maintainsYears returns true if the copyright years in the existing license headers are checked and fixed.
+func (policy YearPolicy) maintainsYears() bool {
+	return policy == ExtendRange || policy == GitLastModified
+}
+
+// endYear returns the last year of the copyright years, or 0 if there is no year.
+func endYear(years string) int {
+	m := lastYear.FindStringSubmatch(strings.TrimSpace(years))
+	if m == nil {
+		return 0
+	}
+	year, _ := strconv.Atoi(m[2])
+	return year
+}
+
+// extendYears extends the copyright years to end with the given year, e.g. "2019" and "2019-2023" are extended to
+// "2019-2026" when the given year is 2026, the years that don't end before the given year are returned as they are.
+func extendYears(years string, year int) string {
+	years = strings.TrimSpace(years)
+	m := lastYear.FindStringSubmatchIndex(years)
+	if m == nil {
+		return years
+	}
+	if last, _ := strconv.Atoi(years[m[4]:m[5]]); last >= year {
+		return years
+	}
+	if m[2] >= 0 { // the last year ends a range, replace it
+		return years[:m[4]] + strconv.Itoa(year)
+	}
+	return years + "-" + strconv.Itoa(year)
+}
+
+// yearAgnosticLicense returns the pattern that matches the normalized license header with any copyright years,
+// or nil if the copyright years are not maintained or the license content has no "[year]" placeholder.
+func (config *ConfigHeader) yearAgnosticLicense() *regexp.Regexp {
+	if !config.License.CopyrightYearPolicy.maintainsYears() {
+		return nil
+	}
+	content := license.Normalize(config.licenseContent(yearPlaceholder))
+	if !strings.Contains(content, yearPlaceholder) {
+		return nil
+	}
+	return regexp.MustCompile(strings.ReplaceAll(regexp.QuoteMeta(content), yearPlaceholder, normalizedYears))
+}
+
+// requiredYear returns the year that the copyright years of the file should end with.
+func (config *ConfigHeader) requiredYear(file string) int {
+	year := time.Now().Year()
+	if config.License.CopyrightYearPolicy != GitLastModified {
+		return year
+	}
+	modified, err := config.history.lastModifiedYear(file)
+	if err != nil {
+		logger.Log.Debugln("Failed to find the last modified year of", file, err)
+		return year
+	}
+	return modified
+}
+
+// checkYears checks the copyright years in the normalized content of the file, found is false if the license header
+// with any copyright years is not found, otherwise upToDate tells whether the copyright years end with the required year.
+func checkYears(file, content string, config *ConfigHeader) (found, upToDate bool) {
+	pattern := config.yearAgnosticLicense()
+	if pattern == nil {
+		return false, false
+	}
+	m := pattern.FindStringSubmatchIndex(content)
+	if m == nil || m[0] >= config.LicenseLocationThreshold {
+		return false, false
+	}
+	return true, endYear(content[m[2]:m[3]]) >= config.requiredYear(file)
+}
+
+// UpdateYears extends the copyright years in the license header of the file to end with the current year,
+// the file is modified by the update itself, so the current year is used whatever the policy is.
+func UpdateYears(file string, config *ConfigHeader, result *Result) error {
+	stat, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	content, enc := decode(raw)
+
+	m := copyrightYears.FindSubmatchIndex(content)
+	if m == nil {
+		return fmt.Errorf("no copyright year is found in the license header: %v", file)
+	}
+	years := extendYears(string(content[m[4]:m[5]]), time.Now().Year())
+	fixed := append(content[:m[4]:m[4]], append([]byte(years), content[m[5]:]...)...)
+
+	if err := writeFixed(file, stat.Mode(), raw, enc.encode(fixed), config); err != nil {
+		return err
+	}
+
+	result.Fix(file)
+
+	return nil
+}
@@ -0,0 +1,278 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendYears(t *testing.T) {
+	tests := []struct {
+		years string
+		want  string
+	}{
+		{"2019", "2019-2026"},
+		{"2019-2023", "2019-2026"},
+		{"2019 - 2023", "2019 - 2026"},
+		{"2019, 2021", "2019, 2021-2026"},
+		{"2026", "2026"},
+		{"2019-2026", "2019-2026"},
+		{"2019-2027", "2019-2027"},
+		{"unknown", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.years, func(t *testing.T) {
+			require.Equal(t, tt.want, extendYears(tt.years, 2026))
+		})
+	}
+}
+
+func TestCheckAndFixStaleYears(t *testing.T) {
+	dir := t.TempDir()
+	year := time.Now().Year()
+	write := func(name, years string) string {
+		file := filepath.Join(dir, name)
+		content := fmt.Sprintf("// Copyright %v Foo\n//\n// Licensed under the Foo License.\n\npackage main\n", years)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+		return file
+	}
+	stale, current := write("stale.go", "2019"), write("current.go", fmt.Sprintf("2019-%v", year))
+
+	config := &ConfigHeader{
+		License: LicenseConfig{
+			Content:             "Copyright [year] Foo\n\nLicensed under the Foo License.",
+			CopyrightYearPolicy: ExtendRange,
+		},
+	}
+	require.NoError(t, config.Finalize())
+
+	var result Result
+	require.NoError(t, CheckFile(stale, config, &result))
+	require.NoError(t, CheckFile(current, config, &result))
+	require.Equal(t, []string{current}, result.Success)
+	require.Equal(t, []string{stale}, result.Failure)
+	require.Equal(t, StaleYear, result.Reason(stale))
+
+	result = Result{}
+	require.NoError(t, Fix(stale, config, &result))
+	require.Equal(t, []string{stale}, result.Fixed)
+	content, err := os.ReadFile(stale)
+	require.NoError(t, err)
+	require.Contains(t, string(content), fmt.Sprintf("// Copyright 2019-%v Foo\n", year))
+
+	result = Result{}
+	require.NoError(t, CheckFile(stale, config, &result))
+	require.Equal(t, []string{stale}, result.Success)
+
+	config.License.CopyrightYearPolicy = PreserveYear
+	result = Result{}
+	require.NoError(t, CheckFile(write("preserved.go", "2019"), config, &result))
+	require.Equal(t, InvalidHeader, result.Reason(filepath.Join(dir, "preserved.go")))
+}
+
+func TestLastModifiedYear(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile("committed.go", []byte("package main"), 0o600))
+	_, err = worktree.Add("committed.go")
+	require.NoError(t, err)
+	_, err = worktree.Commit("init", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+
+	history := new(gitHistory)
+	year, err := history.lastModifiedYear("committed.go")
+	require.NoError(t, err)
+	require.Equal(t, 2020, year)
+
+	require.NoError(t, os.WriteFile("committed.go", []byte("package main\n"), 0o600))
+	year, err = history.lastModifiedYear("committed.go")
+	require.NoError(t, err)
+	require.Equal(t, time.Now().Year(), year)
+
+	require.NoError(t, os.WriteFile("untracked.go", []byte("package main"), 0o600))
+	year, err = history.lastModifiedYear("untracked.go")
+	require.NoError(t, err)
+	require.Equal(t, time.Now().Year(), year)
+}
+
+func TestFirstCommitYear(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(year int, content string) {
+		require.NoError(t, os.WriteFile("main.go", []byte(content), 0o600))
+		_, err := worktree.Add("main.go")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(fmt.Sprintf("file%v.go", year), []byte(content), 0o600))
+		_, err = worktree.Add(fmt.Sprintf("file%v.go", year))
+		require.NoError(t, err)
+		_, err = worktree.Commit(content, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC)},
+		})
+		require.NoError(t, err)
+	}
+	commit(2018, "// Copyright 2018 Foo\n\npackage main\n")
+	commit(2021, "// Copyright 2018 Foo\n\npackage main\n\nfunc main() {}\n")
+	require.NoError(t, os.WriteFile("untracked.go", []byte("package main"), 0o600))
+
+	history := new(gitHistory)
+	for file, first := range map[string]int{"main.go": 2018, "file2018.go": 2018, "file2021.go": 2021, "untracked.go": time.Now().Year()} {
+		year, err := history.firstCommitYear(file)
+		require.NoError(t, err)
+		require.Equal(t, first, year, file)
+	}
+	year, err := history.lastModifiedYear("main.go")
+	require.NoError(t, err)
+	require.Equal(t, 2021, year)
+
+	// the git history isn't needed if the license content has no "[year]" placeholder
+	config := &ConfigHeader{License: LicenseConfig{Content: "Copyright Foo", CopyrightYearSource: GitFirstCommit}}
+	require.NoError(t, config.Finalize())
+	require.Equal(t, "Copyright Foo", config.LicenseContent(config.FileContext("main.go")))
+	require.Nil(t, config.history.first, "the git history shouldn't be walked")
+
+	config = &ConfigHeader{
+		License: LicenseConfig{Content: "Copyright [year] Foo", CopyrightYear: "2000", CopyrightYearSource: GitFirstCommit},
+	}
+	require.NoError(t, config.Finalize())
+	require.Equal(t, "Copyright 2018 Foo", config.LicenseContent(config.FileContext("main.go")))
+	require.Equal(t, fmt.Sprintf("Copyright %v Foo", time.Now().Year()), config.LicenseContent(config.FileContext("untracked.go")))
+	require.Equal(t, "Copyright 2000 Foo", config.GetLicenseContent())
+
+	var result Result
+	require.NoError(t, CheckFile("main.go", config, &result))
+	require.Equal(t, []string{"main.go"}, result.Success)
+
+	config.License.CopyrightYearPolicy = ExtendRange
+	require.Equal(t, fmt.Sprintf("Copyright 2018-%v Foo", time.Now().Year()), config.LicenseContent(config.FileContext("main.go")))
+}
+
+func TestChangedPaths(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(files map[string]string, parents ...plumbing.Hash) plumbing.Hash {
+		for name, content := range files {
+			require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+			_, err := worktree.Add(name)
+			require.NoError(t, err)
+		}
+		hash, err := worktree.Commit("commit", &git.CommitOptions{
+			Author:  &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+			Parents: parents,
+		})
+		require.NoError(t, err)
+		return hash
+	}
+	paths := func(hash plumbing.Hash) []string {
+		c, err := repo.CommitObject(hash)
+		require.NoError(t, err)
+		paths, complete, err := changedPaths(c, false)
+		require.NoError(t, err)
+		require.True(t, complete)
+		return paths
+	}
+
+	root := commit(map[string]string{"a.go": "a", "b.go": "b"})
+	require.ElementsMatch(t, []string{"a.go", "b.go"}, paths(root))
+	left := commit(map[string]string{"a.go": "a2"})
+	require.Equal(t, []string{"a.go"}, paths(left))
+	right := commit(map[string]string{"a.go": "a", "b.go": "b2"}, root)
+	require.Equal(t, []string{"b.go"}, paths(right))
+	merge := commit(map[string]string{"a.go": "a2", "c.go": "c"}, left, right)
+	require.Equal(t, []string{"c.go"}, paths(merge), "the merged changes belong to the commits of the branches")
+}
+
+func TestGitHistoryShallow(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	origin := t.TempDir()
+	require.NoError(t, os.Chdir(origin))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	for _, year := range []int{2018, 2020, 2022} {
+		name := fmt.Sprintf("file%v.go", year)
+		require.NoError(t, os.WriteFile(name, []byte("package main\n"), 0o600))
+		_, err = worktree.Add(name)
+		require.NoError(t, err)
+		_, err = worktree.Commit(name, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC)},
+		})
+		require.NoError(t, err)
+	}
+
+	clone := t.TempDir()
+	_, err = git.PlainClone(clone, false, &git.CloneOptions{URL: "file://" + origin, Depth: 2})
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(clone))
+
+	history := new(gitHistory)
+	for file, first := range map[string]int{"file2018.go": 2020, "file2020.go": 2020, "file2022.go": 2022} {
+		year, err := history.firstCommitYear(file)
+		require.NoError(t, err)
+		require.Equal(t, first, year, "the shallow boundary is taken as the root commit: %v", file)
+	}
+	year, err := history.lastModifiedYear("file2018.go")
+	require.NoError(t, err)
+	require.Equal(t, 2020, year)
+}
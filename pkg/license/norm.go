@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/apache/skywalking-eyes/pkg/logger"
@@ -305,6 +306,20 @@ func SubstantiveTextsNormalizer(text string) string {
 	return text
 }
 
+// AddCommentIndicators adds the leading characters of the comments in the custom comment styles, which are trimmed
+// before the built-in ones, so that the longer indicators like "REM --" are trimmed as a whole.
+func AddCommentIndicators(indicators ...string) {
+	for _, indicator := range indicators {
+		if indicator = strings.TrimSpace(indicator); indicator == "" {
+			continue
+		}
+		re := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(indicator))
+		if !slices.ContainsFunc(commentIndicators, func(r *regexp.Regexp) bool { return r.String() == re.String() }) {
+			commentIndicators = append([]*regexp.Regexp{re}, commentIndicators...)
+		}
+	}
+}
+
 // CommentIndicatorNormalizer trims the leading characters of comments, such as /*, <!--, --, (*, etc..
 func CommentIndicatorNormalizer(text string) string {
 	for _, leadingChars := range commentIndicators {
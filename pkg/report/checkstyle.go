@@ -0,0 +1,75 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+)
+
+// CheckstyleReporter writes the checked files in Checkstyle XML, where the invalid files have an error each.
+type CheckstyleReporter struct{}
+
+type checkstyle struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (*CheckstyleReporter) Format() Format {
+	return "checkstyle"
+}
+
+func (*CheckstyleReporter) Report(w io.Writer, report *Report) error {
+	result := checkstyle{Version: "4.3"}
+
+	for _, section := range report.Sections {
+		for _, file := range section.Files() {
+			if file.Status == Ignored || file.Status == Skipped {
+				continue
+			}
+			f := checkstyleFile{Name: filepath.ToSlash(file.Path)}
+			if file.Status == Invalid {
+				f.Errors = append(f.Errors, checkstyleError{
+					Line:     1,
+					Column:   1,
+					Severity: "error",
+					Message:  file.Message,
+					Source:   toolName + "." + file.Rule,
+				})
+			}
+			result.Files = append(result.Files, f)
+		}
+	}
+
+	return writeXML(w, result)
+}
@@ -0,0 +1,40 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckstyleReporter(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, new(CheckstyleReporter).Report(&buf, testReport()))
+
+	var r checkstyle
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &r))
+	require.Len(t, r.Files, 3)
+	require.Equal(t, "invalid.go", r.Files[1].Name)
+	require.Len(t, r.Files[1].Errors, 1)
+	require.Equal(t, "error", r.Files[1].Errors[0].Severity)
+	require.Empty(t, r.Files[0].Errors)
+	require.Empty(t, r.Files[2].Errors)
+}
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+// JSONSchemaVersion is the version of the JSON report schema, it's increased
+// whenever a backward incompatible change is made to the schema.
+const JSONSchemaVersion = 1
+
+// JSONReporter writes the statuses of all the files in JSON.
+type JSONReporter struct{}
+
+type jsonReport struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Tool          jsonTool      `json:"tool"`
+	Command       string        `json:"command"`
+	Summary       jsonSummary   `json:"summary"`
+	Sections      []jsonSection `json:"sections"`
+}
+
+type jsonTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type jsonSummary struct {
+	Total   int `json:"total"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+	Ignored int `json:"ignored"`
+	Fixed   int `json:"fixed"`
+	Removed int `json:"removed"`
+	Skipped int `json:"skipped"`
+}
+
+type jsonSection struct {
+	Summary jsonSummary `json:"summary"`
+	Files   []jsonFile  `json:"files"`
+}
+
+type jsonFile struct {
+	Path    string `json:"path"`
+	Status  Status `json:"status"`
+	Rule    string `json:"rule,omitempty"`
+	License string `json:"license,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (*JSONReporter) Format() Format {
+	return "json"
+}
+
+func (*JSONReporter) Report(w io.Writer, report *Report) error {
+	r := jsonReport{
+		SchemaVersion: JSONSchemaVersion,
+		Tool:          jsonTool{Name: toolName, Version: report.Version},
+		Command:       report.Command,
+		Sections:      make([]jsonSection, 0, len(report.Sections)),
+	}
+
+	for _, section := range report.Sections {
+		s := jsonSection{Files: []jsonFile{}}
+		for _, file := range section.Files() {
+			s.Files = append(s.Files, jsonFile{
+				Path:    filepath.ToSlash(file.Path),
+				Status:  file.Status,
+				Rule:    file.Rule,
+				License: file.License,
+				Reason:  file.Reason,
+				Message: file.Message,
+			})
+			s.Summary.add(file.Status)
+			r.Summary.add(file.Status)
+		}
+		r.Sections = append(r.Sections, s)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (summary *jsonSummary) add(status Status) {
+	summary.Total++
+	switch status {
+	case Valid:
+		summary.Valid++
+	case Invalid:
+		summary.Invalid++
+	case Ignored:
+		summary.Ignored++
+	case Fixed:
+		summary.Fixed++
+	case Removed:
+		summary.Removed++
+	case Skipped:
+		summary.Skipped++
+	}
+}
@@ -0,0 +1,40 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, new(JSONReporter).Report(&buf, testReport()))
+
+	var r jsonReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &r))
+	require.Equal(t, JSONSchemaVersion, r.SchemaVersion)
+	require.Equal(t, "fix", r.Command)
+	require.Equal(t, jsonSummary{Total: 4, Valid: 1, Invalid: 1, Ignored: 1, Fixed: 1}, r.Summary)
+	require.Len(t, r.Sections, 1)
+	require.Equal(t, r.Summary, r.Sections[0].Summary)
+	require.Equal(t, jsonFile{Path: "fixed.go", Status: Fixed, Message: "The license header is fixed"}, r.Sections[0].Files[0])
+}
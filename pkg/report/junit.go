@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// JUnitReporter writes the statuses of all the files in JUnit XML, one test case per file,
+// so that CI systems such as Jenkins and GitLab can show them as test results.
+type JUnitReporter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+func (*JUnitReporter) Format() Format {
+	return "junit"
+}
+
+func (*JUnitReporter) Report(w io.Writer, report *Report) error {
+	suites := junitTestSuites{Name: toolName}
+
+	for i, section := range report.Sections {
+		suite := junitTestSuite{Name: fmt.Sprintf("%s header %s #%d", toolName, report.Command, i+1)}
+		for _, file := range section.Files() {
+			testCase := junitTestCase{Name: filepath.ToSlash(file.Path), ClassName: toolName + ".header"}
+			switch file.Status {
+			case Invalid:
+				testCase.Failure = &junitFailure{Message: file.Message, Type: file.Rule, Text: file.Message}
+				suite.Failures++
+			case Ignored, Skipped:
+				testCase.Skipped = &junitSkipped{Message: file.Message}
+				suite.Skipped++
+			case Fixed, Removed:
+				testCase.SystemOut = file.Message
+			}
+			suite.Tests++
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	return writeXML(w, suites)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
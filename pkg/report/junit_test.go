@@ -0,0 +1,46 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, new(JUnitReporter).Report(&buf, testReport()))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	require.Equal(t, 4, suites.Tests)
+	require.Equal(t, 1, suites.Failures)
+	require.Equal(t, 1, suites.Skipped)
+	require.Len(t, suites.Suites, 1)
+
+	cases := suites.Suites[0].Cases
+	require.Len(t, cases, 4)
+	require.Equal(t, "invalid.go", cases[2].Name)
+	require.NotNil(t, cases[2].Failure)
+	require.Equal(t, RuleInvalidHeader, cases[2].Failure.Type)
+	require.Nil(t, cases[0].Failure)
+	require.NotNil(t, cases[1].Skipped)
+}
@@ -0,0 +1,262 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package report writes the results of the header commands in machine-readable formats,
+// so that they can be consumed by CI systems and code scanning tools.
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+const (
+	toolName = "license-eye"
+	toolURI  = "https://github.com/apache/skywalking-eyes"
+
+	// RuleInvalidHeader is the rule violated by the files that don't have a valid license header.
+	RuleInvalidHeader = string(header.InvalidHeader)
+	// RuleStaleYear is the rule violated by the files whose copyright years in the license headers are outdated.
+	RuleStaleYear = string(header.StaleYear)
+)
+
+// rules are the descriptions of the rules, keyed by the rule ids.
+var rules = map[string]string{
+	RuleInvalidHeader: "The file doesn't have a valid license header.",
+	RuleStaleYear:     "The copyright year in the license header is outdated.",
+}
+
+// Format is the format of a machine-readable report.
+type Format string
+
+// Reporter writes the report in a specific format.
+type Reporter interface {
+	Format() Format
+	Report(io.Writer, *Report) error
+}
+
+var Reporters = []Reporter{
+	new(SARIFReporter),
+	new(JSONReporter),
+	new(JUnitReporter),
+	new(CheckstyleReporter),
+}
+
+// Formats returns all the supported report formats.
+func Formats() []Format {
+	formats := make([]Format, len(Reporters))
+	for i, reporter := range Reporters {
+		formats[i] = reporter.Format()
+	}
+	return formats
+}
+
+// ParseFormat parses the format name given by users.
+func ParseFormat(name string) (Format, error) {
+	if reporter := find(Format(name)); reporter != nil {
+		return reporter.Format(), nil
+	}
+	return "", fmt.Errorf("unsupported report format %q, supported formats are %v", name, Formats())
+}
+
+func find(format Format) Reporter {
+	for _, reporter := range Reporters {
+		if reporter.Format() == format {
+			return reporter
+		}
+	}
+	return nil
+}
+
+// Status is the status of a file after a header command.
+type Status string
+
+const (
+	Valid   Status = "valid"
+	Invalid Status = "invalid"
+	Ignored Status = "ignored"
+	Fixed   Status = "fixed"
+	Removed Status = "removed"
+	Skipped Status = "skipped"
+)
+
+// skipMessages are the human-readable explanations of the skip reasons.
+var skipMessages = map[header.SkipReason]string{
+	header.BinaryFile:       "The file is skipped as it's not a text file",
+	header.UnsupportedStyle: "The file is skipped as its comment style is unsupported",
+	header.TooLarge:         "The file is skipped as it's larger than the max-file-size",
+}
+
+// ignoreMessages are the human-readable explanations of the ignore reasons.
+var ignoreMessages = map[header.IgnoreReason]string{
+	header.Generated: "The file is ignored as it's generated",
+}
+
+// File is the status of a single file in the report.
+type File struct {
+	Path   string
+	Status Status
+	// Rule is the rule violated by the invalid file.
+	Rule string
+	// License is the accepted license that the valid file matched, if it's known.
+	License string
+	// Reason is why the skipped file is skipped, or why the ignored file is ignored other than by the paths-ignore.
+	Reason string
+	// Message explains the status, e.g. why the license header of an invalid file is invalid.
+	Message string
+}
+
+// Section is the result of the files checked against one header section of the configuration.
+type Section struct {
+	Result *header.Result
+	// Details explains why the files in Result.Failure don't have a valid license header, or how they are fixed, keyed by file path.
+	Details map[string]string
+}
+
+// Report is the machine-readable report of a header command.
+type Report struct {
+	// Command is the header command that generates the report, e.g. check, fix.
+	Command string
+	// Version is the version of license-eye that generates the report.
+	Version  string
+	Sections []*Section
+}
+
+// Add adds the result of a header section to the report.
+func (report *Report) Add(result *header.Result, details map[string]string) {
+	report.Sections = append(report.Sections, &Section{Result: result, Details: details})
+}
+
+// Write writes the report in the given format to the output file, or to the standard output if output is empty.
+func Write(report *Report, format Format, output string) error {
+	reporter := find(format)
+	if reporter == nil {
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+
+	w := io.Writer(os.Stdout)
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return reporter.Report(w, report)
+}
+
+// Files returns the statuses of all the files in the section, sorted by the file paths.
+func (section *Section) Files() []File {
+	result := section.Result
+	fixed := make(map[string]bool, len(result.Fixed))
+	for _, file := range result.Fixed {
+		fixed[file] = true
+	}
+	removed := make(map[string]bool, len(result.Removed))
+	for _, file := range result.Removed {
+		removed[file] = true
+	}
+
+	skipped := make(map[string]bool, len(result.Skipped))
+	for _, file := range result.Skipped {
+		skipped[file] = true
+	}
+
+	files := make([]File, 0, len(result.Success)+len(result.Failure)+len(result.Ignored)+len(result.Skipped))
+	for _, file := range result.Success {
+		if removed[file] {
+			files = append(files, File{Path: file, Status: Removed, Message: "The license header is removed"})
+		} else {
+			files = append(files, File{Path: file, Status: Valid, License: result.License(file)})
+		}
+	}
+	for _, file := range result.Failure {
+		switch {
+		case fixed[file]:
+			files = append(files, File{Path: file, Status: Fixed, Message: section.fixedMessage(file)})
+		case skipped[file]:
+			// the invalid file that cannot be fixed
+			files = append(files, section.skippedFile(file))
+			delete(skipped, file)
+		default:
+			reason := result.Reason(file)
+			files = append(files, File{Path: file, Status: Invalid, Rule: string(reason), Message: section.message(file, reason)})
+		}
+	}
+	for _, file := range result.Ignored {
+		reason := result.IgnoreReason(file)
+		files = append(files, File{Path: file, Status: Ignored, Reason: string(reason), Message: ignoreMessages[reason]})
+	}
+	for _, file := range result.Skipped {
+		if skipped[file] {
+			files = append(files, section.skippedFile(file))
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// invalid returns the invalid files of the section, sorted by the file paths.
+func (section *Section) invalid() []File {
+	var files []File
+	for _, file := range section.Files() {
+		if file.Status == Invalid {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// fixedMessage returns the human-readable explanation of how the file is fixed.
+func (section *Section) fixedMessage(file string) string {
+	msg := "The license header is fixed"
+	if detail := section.Details[file]; detail != "" {
+		msg += ": " + detail
+	}
+	return msg
+}
+
+// skippedFile returns the status of the skipped file.
+func (section *Section) skippedFile(file string) File {
+	reason := section.Result.SkipReason(file)
+	msg := skipMessages[reason]
+	if detail := section.Details[file]; detail != "" {
+		msg += ": " + detail
+	}
+	return File{Path: file, Status: Skipped, Reason: string(reason), Message: msg}
+}
+
+// message returns the human-readable explanation of why the file is invalid.
+func (section *Section) message(file string, reason header.Reason) string {
+	msg := "The file doesn't have a valid license header"
+	if reason == header.StaleYear {
+		msg = "The copyright year in the license header is outdated"
+	}
+	if detail := section.Details[file]; detail != "" {
+		msg += ": " + detail
+	}
+	return msg
+}
@@ -0,0 +1,109 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+// testReport returns a report of a fix command, where one invalid file is fixed and another one is not.
+func testReport() *Report {
+	var result header.Result
+	result.Succeed("valid.go")
+	result.Fail("invalid.go")
+	result.Fail("fixed.go")
+	result.Fix("fixed.go")
+	result.Ignore("ignored.md")
+
+	r := Report{Command: "fix", Version: "v1.0.0"}
+	r.Add(&result, map[string]string{"invalid.go": "unsupported file: invalid.go"})
+	return &r
+}
+
+func TestSectionFiles(t *testing.T) {
+	require.Equal(t, []File{
+		{Path: "fixed.go", Status: Fixed, Message: "The license header is fixed"},
+		{Path: "ignored.md", Status: Ignored},
+		{
+			Path:    "invalid.go",
+			Status:  Invalid,
+			Rule:    RuleInvalidHeader,
+			Message: "The file doesn't have a valid license header: unsupported file: invalid.go",
+		},
+		{Path: "valid.go", Status: Valid},
+	}, testReport().Sections[0].Files())
+}
+
+func TestSectionFilesStaleYear(t *testing.T) {
+	var result header.Result
+	result.FailWithReason("stale.go", header.StaleYear)
+
+	r := Report{Command: "check"}
+	r.Add(&result, nil)
+	require.Equal(t, []File{
+		{Path: "stale.go", Status: Invalid, Rule: RuleStaleYear, Message: "The copyright year in the license header is outdated"},
+	}, r.Sections[0].Files())
+}
+
+func TestSectionFilesSkipped(t *testing.T) {
+	var result header.Result
+	result.Skip("logo.png", header.BinaryFile)
+	result.Fail("data.unknown")
+	result.Skip("data.unknown", header.UnsupportedStyle)
+
+	r := Report{Command: "fix"}
+	r.Add(&result, map[string]string{"data.unknown": "unsupported file: data.unknown"})
+	require.Equal(t, []File{
+		{
+			Path:    "data.unknown",
+			Status:  Skipped,
+			Reason:  string(header.UnsupportedStyle),
+			Message: "The file is skipped as its comment style is unsupported: unsupported file: data.unknown",
+		},
+		{Path: "logo.png", Status: Skipped, Reason: string(header.BinaryFile), Message: "The file is skipped as it's not a text file"},
+	}, r.Sections[0].Files())
+	require.Equal(t, "Totally checked 2 files, valid: 0, invalid: 1, ignored: 0, fixed: 0, skipped: 2", result.String())
+}
+
+func TestSectionFilesIgnored(t *testing.T) {
+	var result header.Result
+	result.Ignore("vendor/lib.go")
+	result.IgnoreWithReason("api/api.pb.go", header.Generated)
+
+	r := Report{Command: "check"}
+	r.Add(&result, nil)
+	require.Equal(t, []File{
+		{Path: "api/api.pb.go", Status: Ignored, Reason: string(header.Generated), Message: "The file is ignored as it's generated"},
+		{Path: "vendor/lib.go", Status: Ignored},
+	}, r.Sections[0].Files())
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"sarif", "json", "junit", "checkstyle"} {
+		format, err := ParseFormat(name)
+		require.NoError(t, err)
+		require.Equal(t, Format(name), format)
+	}
+
+	_, err := ParseFormat("xml")
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	var result header.Result
+	result.Succeed("valid.go")
+	result.Fail("b/invalid.go")
+	result.Fail("a/missing.py")
+
+	var r Report
+	r.Version = "v1.0.0"
+	r.Add(&result, map[string]string{"b/invalid.go": "[-apache-] {+apache2+} ..."})
+
+	var buf bytes.Buffer
+	require.NoError(t, new(SARIFReporter).Report(&buf, &r))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	require.Equal(t, "license-eye", run.Tool.Driver.Name)
+	require.Equal(t, "v1.0.0", run.Tool.Driver.Version)
+	require.Len(t, run.Results, 2)
+
+	missing, invalid := run.Results[0], run.Results[1]
+	require.Equal(t, RuleInvalidHeader, missing.RuleID)
+	require.Equal(t, "a/missing.py", missing.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, "The file doesn't have a valid license header", missing.Message.Text)
+	require.Equal(t, "b/invalid.go", invalid.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, 1, invalid.Locations[0].PhysicalLocation.Region.StartLine)
+	require.Contains(t, invalid.Message.Text, "{+apache2+}")
+}
@@ -139,6 +139,9 @@ func Header(result *header2.Result, config *header2.ConfigHeader) error {
 			if !strings.HasSuffix(invalidFile, changedFile.GetFilename()) {
 				continue
 			}
+			if result.Reason(invalidFile) != header2.InvalidHeader {
+				continue // suggesting a whole new license header doesn't help
+			}
 			blob, _, err := gh.Git.GetBlob(ctx, owner, repo, changedFile.GetSHA())
 			if err != nil {
 				logger.Log.Warnln("Failed to get blob:", changedFile.GetFilename(), changedFile.GetSHA())
@@ -149,7 +152,7 @@ func Header(result *header2.Result, config *header2.ConfigHeader) error {
 				logger.Log.Warnln("Failed to determine the comment style of file:", changedFile.GetFilename())
 				continue
 			}
-			header, err := header2.GenerateLicenseHeader(style, config)
+			header, err := header2.GenerateLicenseHeader(style, config, config.FileContext(changedFile.GetFilename()))
 			if err != nil {
 				logger.Log.Warnln("Failed to generate comment header:", changedFile.GetFilename())
 				continue